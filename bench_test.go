@@ -0,0 +1,210 @@
+package validation_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+type benchUser struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Age      int    `json:"age"`
+}
+
+var benchFields = []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*benchUser).Email
+		},
+		Rules: []validation.Rule{
+			rule.StrRequired("required"),
+			rule.StrMaxLen(64, "too long"),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*benchUser).Name
+		},
+		Rules: []validation.Rule{
+			rule.StrLen(2, 32, "invalid length"),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*benchUser).Password
+		},
+		Rules: []validation.Rule{
+			rule.StrRequired("required"),
+			rule.StrMinLen(8, "too short"),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*benchUser).Age
+		},
+		Rules: []validation.Rule{
+			rule.Between(18, 120, "out of range"),
+		},
+	},
+}
+
+var benchValue = &benchUser{
+	Email:    "user@mail.com",
+	Name:     "user",
+	Password: "12345678",
+	Age:      30,
+}
+
+// The code below is the Struct implementation preceding Compile, copied as
+// is from the initial revision of the package with the identifiers qualified.
+// It is kept to measure the gain of precompiled schemas.
+
+var (
+	legacyErrorArgs = validation.Panic{Err: errors.New("expected pointer to struct")}
+	legacyErrorAttr = validation.Panic{Err: errors.New("Attr must return a pointer")}
+)
+
+func legacyPanicRule(err error) validation.Rule {
+	return func(interface{}) func(interface{}) error {
+		return func(interface{}) error {
+			return err
+		}
+	}
+}
+
+type legacyStructRule struct {
+	ftab   map[uintptr]string
+	fields []validation.Field
+}
+
+func legacyStruct(v interface{}, tag string, fields []validation.Field) validation.Rule {
+	tp := reflect.TypeOf(v)
+	if tp.Kind() != reflect.Ptr {
+		return legacyPanicRule(legacyErrorArgs)
+	}
+
+	tp = tp.Elem()
+	if tp.Kind() != reflect.Struct {
+		return legacyPanicRule(legacyErrorArgs)
+	}
+
+	ftab := map[uintptr]string{}
+	for i := 0; i < tp.NumField(); i++ {
+		ft := tp.Field(i)
+		if tag == "" {
+			ftab[ft.Offset] = ft.Name
+		} else {
+			fname := ft.Tag.Get(tag)
+			if fname == "" {
+				fname = ft.Name
+			}
+			ftab[ft.Offset] = fname
+		}
+	}
+
+	s := legacyStructRule{
+		ftab:   ftab,
+		fields: fields,
+	}
+
+	return s.validate
+}
+
+func (s legacyStructRule) validate(ctx interface{}) func(interface{}) error {
+	return func(v interface{}) error {
+		tp := reflect.TypeOf(v)
+		if tp.Kind() != reflect.Ptr {
+			return legacyErrorArgs
+		}
+
+		tp = tp.Elem()
+		if tp.Kind() != reflect.Struct {
+			return legacyErrorArgs
+		}
+
+		self := reflect.ValueOf(v).Pointer()
+
+		errs := []error{}
+		for _, f := range s.fields {
+			attr := f.Attr(v)
+
+			fv := reflect.ValueOf(attr)
+			if fv.Kind() != reflect.Ptr {
+				return legacyErrorAttr
+			}
+
+			name := ""
+			if attr != v {
+				name = s.ftab[fv.Pointer()-self]
+			}
+
+			fe := []error{}
+			for _, rule := range f.Rules {
+				if err := rule(ctx)(attr); err != nil {
+					if _, ok := err.(validation.Panic); ok {
+						return err
+					}
+					if e, ok := err.(validation.Errors); ok {
+						for _, i := range e {
+							fe = append(fe, i)
+						}
+					} else {
+						fe = append(fe, err)
+					}
+				}
+			}
+
+			if len(fe) > 0 {
+				errs = append(errs, validation.StructError{Field: name, Errors: fe})
+			}
+		}
+
+		if len(errs) > 0 {
+			return validation.Errors(errs)
+		}
+
+		return nil
+	}
+}
+
+func BenchmarkStructLegacy(b *testing.B) {
+	validate := legacyStruct(&benchUser{}, "json", benchFields)(nil)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := validate(benchValue); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStruct(b *testing.B) {
+	validate := validation.Struct(&benchUser{}, "json", benchFields)(nil)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := validate(benchValue); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlan(b *testing.B) {
+	s, err := validation.Compile(&benchUser{}, "json", benchFields)
+	if err != nil {
+		b.Fatal(err)
+	}
+	plan := s.Bind(nil)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := plan.Validate(benchValue); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
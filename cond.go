@@ -0,0 +1,54 @@
+package validation
+
+// Cond represents a predicate over the validation context and a value. A
+// Cond of a struct level field gets the struct itself, so it can check the
+// struct fields.
+type Cond func(ctx, v interface{}) bool
+
+// When creates a rule which applies the rules provided only if cond holds.
+func When(cond Cond, rules ...Rule) Rule {
+	return Described(func(ctx interface{}) func(interface{}) error {
+		fn := Rules(rules)(ctx)
+		return func(v interface{}) error {
+			if !cond(ctx, v) {
+				return nil
+			}
+			return fn(v)
+		}
+	}, func() Descriptor {
+		return Descriptor{Name: NameWhen, Rules: DescribeAll(rules)}
+	})
+}
+
+// Unless creates a rule which applies the rules provided only if cond does
+// not hold.
+func Unless(cond Cond, rules ...Rule) Rule {
+	return When(Not(cond), rules...)
+}
+
+// Not negates a Cond.
+func Not(cond Cond) Cond {
+	return func(ctx, v interface{}) bool {
+		return !cond(ctx, v)
+	}
+}
+
+// On creates a struct level rule which applies the rules provided to the
+// field pointed by attr. Errors are reported under the field, so On allows
+// to validate a field depending on the other fields, e.g.
+//
+//	When(cond, On(attr, rules...))
+func On(attr Attr, rules ...Rule) Rule {
+	return Described(func(ctx interface{}) func(interface{}) error {
+		fn := Rules(rules)(ctx)
+		return func(v interface{}) error {
+			err := fn(attr(v))
+			if err == nil || Fatal(err) {
+				return err
+			}
+			return FieldError(ctx, v, attr, err.(Errors)...)
+		}
+	}, func() Descriptor {
+		return Descriptor{Name: NameOn, Rules: DescribeAll(rules)}
+	})
+}
@@ -0,0 +1,121 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+func countryIs(country string) validation.Cond {
+	return func(ctx, v interface{}) bool {
+		return v.(*Address).Country == country
+	}
+}
+
+func zipCodeAttr(v interface{}) interface{} {
+	return &v.(*Address).ZipCode
+}
+
+var addressRuleCond = validation.Struct(&Address{}, "json", []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return v
+		},
+		Rules: []validation.Rule{
+			validation.When(countryIs("US"),
+				validation.On(zipCodeAttr,
+					validation.Func(stringRequired),
+					validation.Func(zipCode),
+				),
+			),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Address).City
+		},
+		Rules: []validation.Rule{
+			validation.Unless(func(ctx, v interface{}) bool {
+				return *v.(*string) == ""
+			}, validation.Func(startsUpperCase)),
+		},
+	},
+})
+
+func TestWhen(t *testing.T) {
+	t.Run("ErrorIfCondHolds", func(t *testing.T) {
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field:  "zipCode",
+				Errors: []error{errors.New(eRequired)},
+			},
+		})
+		require.Equal(t, exp, addressRuleCond(nil)(&Address{Country: "US"}))
+	})
+	t.Run("ErrorIfCondHoldsInContext", func(t *testing.T) {
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field:  "zipCode",
+				Errors: []error{errors.New(eZipCode)},
+			},
+		})
+		v := &Address{Country: "US", ZipCode: "abc"}
+		require.Equal(t, exp, validation.Validate(context.Background(), addressRuleCond, v))
+	})
+	t.Run("CustomContextPassedAsIs", func(t *testing.T) {
+		var got interface{}
+		r := validation.Struct(&Address{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return v
+				},
+				Rules: []validation.Rule{
+					func(ctx interface{}) func(interface{}) error {
+						got = ctx
+						return func(interface{}) error { return nil }
+					},
+				},
+			},
+		})
+		require.NoError(t, r([]string{"a"})(&Address{}))
+		require.Equal(t, []string{"a"}, got)
+	})
+	t.Run("OkIfCondDoesNotHold", func(t *testing.T) {
+		require.NoError(t, addressRuleCond(nil)(&Address{Country: "Russia"}))
+	})
+}
+
+func TestUnless(t *testing.T) {
+	t.Run("ErrorIfCondDoesNotHold", func(t *testing.T) {
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field:  "city",
+				Errors: []error{errors.New(eStartsUpperCase)},
+			},
+		})
+		require.Equal(t, exp, addressRuleCond(nil)(&Address{City: "moscow"}))
+	})
+	t.Run("OkIfCondHolds", func(t *testing.T) {
+		require.NoError(t, addressRuleCond(nil)(&Address{}))
+	})
+}
+
+func TestWhenContext(t *testing.T) {
+	strict := validation.NewKey[bool]("strict")
+	rule := validation.When(func(ctx, v interface{}) bool {
+		s, _ := validation.Value(ctx, strict)
+		return s
+	}, validation.Func(stringRequired))
+
+	v := ""
+	ctx := context.Background()
+	require.NoError(t, validation.Validate(ctx, rule, &v))
+
+	ctx = validation.WithValue(ctx, strict, true)
+	exp := validation.Errors([]error{errors.New(eRequired)})
+	require.Equal(t, exp, validation.Validate(ctx, rule, &v))
+}
@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+)
+
+// Canceled represents a validation interrupted by its context.
+type Canceled struct {
+	Err error
+}
+
+// Error returns string representation of Canceled.
+func (e Canceled) Error() string {
+	return fmt.Sprintf("validation canceled: %v", e.Err)
+}
+
+// Unwrap returns the context error.
+func (e Canceled) Unwrap() error {
+	return e.Err
+}
+
+// Key represents a key of a typed value stored in a validation context.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey creates new typed context key.
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// String returns the key name.
+func (k *Key[T]) String() string {
+	return k.name
+}
+
+// WithValue returns a copy of ctx holding the typed value v.
+func WithValue[T any](ctx context.Context, key *Key[T], v T) context.Context {
+	return context.WithValue(ctx, key, v)
+}
+
+// Value gets the typed value from a validation context. It returns false if
+// the context is not a context.Context or does not hold the value.
+func Value[T any](ctx interface{}, key *Key[T]) (T, bool) {
+	var v T
+	c, ok := ctx.(context.Context)
+	if !ok {
+		return v, false
+	}
+	v, ok = c.Value(key).(T)
+	return v, ok
+}
+
+// Validate validates v against the rule provided. The validation stops as
+// soon as ctx is canceled or its deadline passes, in this case Canceled is
+// returned. Go panics of the rules are recovered and returned as Panic.
+func Validate(ctx context.Context, rule Rule, v interface{}) (err error) {
+	if err := Interrupted(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = Locate(Recovered(r), rule, v)
+		}
+		if _, ok := err.(Panic); ok && must(ctx) {
+			panic(err)
+		}
+	}()
+
+	return rule(ctx)(v)
+}
+
+// Interrupted returns Canceled if ctx is a context.Context which is done,
+// otherwise nil.
+func Interrupted(ctx interface{}) error {
+	if c, ok := ctx.(context.Context); ok {
+		if err := c.Err(); err != nil {
+			return Canceled{Err: err}
+		}
+	}
+	return nil
+}
+
+// Fatal reports whether err stops the validation, i.e. it is either Panic or
+// Canceled.
+func Fatal(err error) bool {
+	switch err.(type) {
+	case Panic, Canceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Deferred is implemented by errors which outcome is known only after the
+// validation, e.g. lookups resolved by a batched store call. Deferred errors
+// stay in the error tree at the paths of the values checked, but they are
+// not failures in bail modes, so the rules following them still run.
+type Deferred interface {
+	error
+	Deferred()
+}
+
+// Failed reports whether err fails the validation, i.e. it is not nil and
+// has leaves other than Deferred errors.
+func Failed(err error) bool {
+	if err == nil {
+		return false
+	}
+	return Walk(err, func(_ Path, leaf error) error {
+		if _, ok := leaf.(Deferred); ok {
+			return nil
+		}
+		return errStop
+	}) != nil
+}
@@ -0,0 +1,148 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+var usersKey = validation.NewKey[[]string]("users")
+
+func emailUniqCtx(ctx interface{}) func(interface{}) error {
+	db, _ := validation.Value(ctx, usersKey)
+	return func(v interface{}) error {
+		s := v.(*string)
+		for _, u := range db {
+			if u == *s {
+				return errors.New(eDuplicate)
+			}
+		}
+		return nil
+	}
+}
+
+func cancelRule(cancel context.CancelFunc) validation.Rule {
+	return validation.Func(func(interface{}) error {
+		cancel()
+		return nil
+	})
+}
+
+func TestValue(t *testing.T) {
+	t.Run("FalseIfNotContext", func(t *testing.T) {
+		_, ok := validation.Value(usersDB, usersKey)
+		require.False(t, ok)
+	})
+	t.Run("FalseIfNotFound", func(t *testing.T) {
+		_, ok := validation.Value(context.Background(), usersKey)
+		require.False(t, ok)
+	})
+	t.Run("ValueIfFound", func(t *testing.T) {
+		ctx := validation.WithValue(context.Background(), usersKey, usersDB)
+		v, ok := validation.Value(ctx, usersKey)
+		require.True(t, ok)
+		require.Equal(t, usersDB, v)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	rule := validation.Struct(&User{}, ``, []validation.Field{
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*User).Email
+			},
+			Rules: []validation.Rule{emailUniqCtx},
+		},
+	})
+
+	t.Run("ErrorIfValueRuleFails", func(t *testing.T) {
+		ctx := validation.WithValue(context.Background(), usersKey, usersDB)
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field:  "Email",
+				Errors: []error{errors.New(eDuplicate)},
+			},
+		})
+		require.Equal(t, exp, validation.Validate(ctx, rule, &User{Email: usersDB[0]}))
+	})
+	t.Run("OkIfValueRulePasses", func(t *testing.T) {
+		ctx := validation.WithValue(context.Background(), usersKey, usersDB)
+		require.NoError(t, validation.Validate(ctx, rule, &User{Email: "user3@mail.com"}))
+	})
+	t.Run("CanceledIfContextDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := validation.Validate(ctx, rule, &User{})
+		require.Equal(t, validation.Canceled{Err: context.Canceled}, err)
+	})
+	t.Run("CanceledIfDeadlineExceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 0)
+		defer cancel()
+		err := validation.Validate(ctx, rule, &User{})
+		require.Equal(t, validation.Canceled{Err: context.DeadlineExceeded}, err)
+	})
+}
+
+func TestValidateStopsIfCanceled(t *testing.T) {
+	t.Run("Struct", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		rule := validation.Struct(&Address{}, ``, []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Address).Country
+				},
+				Rules: []validation.Rule{cancelRule(cancel)},
+			},
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Address).ZipCode
+				},
+				Rules: []validation.Rule{validation.Func(stringRequired)},
+			},
+		})
+		err := validation.Validate(ctx, rule, &Address{})
+		require.Equal(t, validation.Canceled{Err: context.Canceled}, err)
+	})
+	t.Run("Rules", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		rule := validation.Rules([]validation.Rule{
+			cancelRule(cancel),
+			validation.Func(stringRequired),
+		})
+		v := ""
+		err := validation.Validate(ctx, rule, &v)
+		require.Equal(t, validation.Canceled{Err: context.Canceled}, err)
+		require.True(t, validation.Fatal(err))
+	})
+}
+
+type deferred struct{}
+
+func (deferred) Error() string { return "deferred" }
+
+func (deferred) Deferred() {}
+
+func TestFailed(t *testing.T) {
+	require.False(t, validation.Failed(nil))
+	require.False(t, validation.Failed(deferred{}))
+	require.False(t, validation.Failed(validation.Errors{
+		validation.StructError{Field: "a", Errors: validation.Errors{deferred{}}},
+	}))
+	require.True(t, validation.Failed(validation.Errors{
+		validation.StructError{Field: "a", Errors: validation.Errors{deferred{}}},
+		validation.StructError{Field: "b", Errors: validation.Errors{errors.New(eRequired)}},
+	}))
+
+	rules := validation.RulesMode(validation.ModeBailRule, []validation.Rule{
+		func(interface{}) func(interface{}) error {
+			return func(interface{}) error { return deferred{} }
+		},
+		validation.Func(stringRequired),
+	})
+	v := ""
+	require.Equal(t, validation.Errors{deferred{}, errors.New(eRequired)}, rules(nil)(&v))
+}
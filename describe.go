@@ -0,0 +1,223 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Names of the rules described by the package.
+const (
+	NameCustom = ""
+	NameRules  = "rules"
+	NameStruct = "struct"
+	NameWhen   = "when"
+	NameOn     = "on"
+)
+
+// Descriptor describes what a rule checks. Rules created by Func and other
+// custom rules are not described and have the NameCustom name.
+type Descriptor struct {
+	// Name identifies the rule, e.g. "str.min_len".
+	Name string
+	// Message is the message of the rule errors.
+	Message string
+	// Params holds the rule parameters, the keys are the same as the keys of
+	// the validation.Error Params.
+	Params Params
+	// Type is the type of the value validated if the rule knows it.
+	Type reflect.Type
+	// Rules describes the child rules applied to the value itself.
+	Rules []Descriptor
+	// Items describes the child rules applied to slice items or map values.
+	Items []Descriptor
+	// Keys describes the child rules applied to map keys.
+	Keys []Descriptor
+	// Fields describes the fields of a struct.
+	Fields []FieldDescriptor
+}
+
+// FieldDescriptor describes a struct field.
+type FieldDescriptor struct {
+	// Path is the path of the field within the struct, it is nil if the path
+	// can not be resolved at compile time.
+	Path []string
+	// Type is the field type, it is nil if the path is nil.
+	Type reflect.Type
+	// Rules describes the field rules.
+	Rules []Descriptor
+	// Ignored reports whether the field is ignored by the NameFunc, e.g. it
+	// is tagged json:"-". Its Path holds the name errors are reported at.
+	Ignored bool
+}
+
+// probe is the validation context used to query rule descriptors.
+type probe struct {
+	describe func() Descriptor
+}
+
+// Described attaches a descriptor to the rule r. The descriptor is built
+// lazily by the function d when the rule is queried.
+func Described(r Rule, d func() Descriptor) Rule {
+	return func(ctx interface{}) func(interface{}) error {
+		if p, ok := ctx.(*probe); ok {
+			p.describe = d
+			return nil
+		}
+		return r(ctx)
+	}
+}
+
+// Describe returns the descriptor of the rule r. A described rule binds the
+// probe to nothing, so the descriptor is taken only if r returns nil. Custom
+// rules wrapping described ones bind them with the probe too, but return
+// functions of their own, such rules are custom whatever they wrap.
+func Describe(r Rule) Descriptor {
+	p := &probe{}
+	described := false
+	func() {
+		// Custom rules are bound with the probe, they might fail on it.
+		defer func() {
+			recover()
+		}()
+		described = r(p) == nil
+	}()
+
+	if !described || p.describe == nil {
+		return Descriptor{}
+	}
+	return p.describe()
+}
+
+// DescribeAll returns the descriptors of the rules provided.
+func DescribeAll(rules []Rule) []Descriptor {
+	ds := make([]Descriptor, len(rules))
+	for i, r := range rules {
+		ds[i] = Describe(r)
+	}
+	return ds
+}
+
+// describe describes the schema.
+func (s *Schema) describe() Descriptor {
+	d := Descriptor{Name: NameStruct, Type: s.typ.Elem()}
+	for _, f := range s.fields {
+		if f.self {
+			d.Rules = append(d.Rules, DescribeAll(f.Rules)...)
+			continue
+		}
+		d.Fields = append(d.Fields, FieldDescriptor{
+			Path:    f.path,
+			Type:    f.typ,
+			Rules:   DescribeAll(f.Rules),
+			Ignored: f.ignored,
+		})
+	}
+	return d
+}
+
+// Path segments of the slice items, map values and map keys passed to the
+// Walk visitor.
+const (
+	SegmentItems = "[]"
+	SegmentKeys  = "{}"
+)
+
+// Walk calls fn for the descriptor and all its descendants in depth-first
+// order. The path holds the names of the struct fields, SegmentItems for
+// slice items and map values and SegmentKeys for map keys. The children of a
+// descriptor are skipped if fn returns false.
+func (d Descriptor) Walk(fn func(path []string, d Descriptor) bool) {
+	d.walk(nil, fn)
+}
+
+func (d Descriptor) walk(path []string, fn func([]string, Descriptor) bool) {
+	if !fn(path, d) {
+		return
+	}
+	for _, c := range d.Rules {
+		c.walk(path, fn)
+	}
+	for _, f := range d.Fields {
+		for _, c := range f.Rules {
+			c.walk(join(path, f.Path...), fn)
+		}
+	}
+	for _, c := range d.Keys {
+		c.walk(join(path, SegmentKeys), fn)
+	}
+	for _, c := range d.Items {
+		c.walk(join(path, SegmentItems), fn)
+	}
+}
+
+func join(path []string, names ...string) []string {
+	p := make([]string, 0, len(path)+len(names))
+	return append(append(p, path...), names...)
+}
+
+// Find returns the descriptor and its descendants having the name provided.
+func (d Descriptor) Find(name string) []Descriptor {
+	var ds []Descriptor
+	d.Walk(func(_ []string, c Descriptor) bool {
+		if c.Name == name {
+			ds = append(ds, c)
+		}
+		return true
+	})
+	return ds
+}
+
+// String returns an indented tree representation of the descriptor.
+func (d Descriptor) String() string {
+	var b strings.Builder
+	d.format(&b, 0)
+	return b.String()
+}
+
+func (d Descriptor) format(b *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	name := d.Name
+	if name == NameCustom {
+		name = "<custom>"
+	}
+	fmt.Fprintf(b, "%s%s", indent, name)
+
+	keys := make([]string, 0, len(d.Params))
+	for k := range d.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, " %s=%v", k, d.Params[k])
+	}
+
+	if d.Message != "" {
+		fmt.Fprintf(b, " %q", d.Message)
+	}
+	b.WriteString("\n")
+
+	for _, c := range d.Rules {
+		c.format(b, depth+1)
+	}
+	for _, f := range d.Fields {
+		fmt.Fprintf(b, "%s  %s:\n", indent, strings.Join(f.Path, "."))
+		for _, c := range f.Rules {
+			c.format(b, depth+2)
+		}
+	}
+	if len(d.Keys) > 0 {
+		fmt.Fprintf(b, "%s  %s:\n", indent, SegmentKeys)
+		for _, c := range d.Keys {
+			c.format(b, depth+2)
+		}
+	}
+	if len(d.Items) > 0 {
+		fmt.Fprintf(b, "%s  %s:\n", indent, SegmentItems)
+		for _, c := range d.Items {
+			c.format(b, depth+2)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Run("EmptyIfFunc", func(t *testing.T) {
+		d := validation.Describe(validation.Func(email))
+		require.Equal(t, validation.Descriptor{}, d)
+	})
+	t.Run("EmptyIfRulePanics", func(t *testing.T) {
+		d := validation.Describe(func(ctx interface{}) func(interface{}) error {
+			ctx.(context.Context).Value(nil)
+			return nil
+		})
+		require.Equal(t, validation.Descriptor{}, d)
+	})
+	t.Run("EmptyIfWrapsDescribed", func(t *testing.T) {
+		short := func(ctx interface{}) func(interface{}) error {
+			long := rule.StrMinLen(3, "long")(ctx)
+			return func(v interface{}) error {
+				if long(v) == nil {
+					return errors.New("should be short")
+				}
+				return nil
+			}
+		}
+		require.Equal(t, validation.Descriptor{}, validation.Describe(short))
+		s := "ab"
+		require.Nil(t, short(nil)(&s))
+	})
+	t.Run("EmptyIfPanicsAfterDescribed", func(t *testing.T) {
+		d := validation.Describe(func(ctx interface{}) func(interface{}) error {
+			rule.StrMinLen(3, "long")(ctx)
+			ctx.(context.Context).Value(nil)
+			return nil
+		})
+		require.Equal(t, validation.Descriptor{}, d)
+	})
+	t.Run("Described", func(t *testing.T) {
+		r := validation.Described(validation.Func(email), func() validation.Descriptor {
+			return validation.Descriptor{Name: "email"}
+		})
+		require.Equal(t, validation.Descriptor{Name: "email"}, validation.Describe(r))
+		s := "user@mail.com"
+		require.Nil(t, r(nil)(&s))
+	})
+	t.Run("Rules", func(t *testing.T) {
+		d := validation.Describe(validation.Rules([]validation.Rule{
+			validation.Func(email),
+			validation.When(always, validation.Func(email)),
+		}))
+		require.Equal(t, validation.Descriptor{
+			Name: validation.NameRules,
+			Rules: []validation.Descriptor{
+				{},
+				{
+					Name:  validation.NameWhen,
+					Rules: []validation.Descriptor{{}},
+				},
+			},
+		}, d)
+	})
+	t.Run("Struct", func(t *testing.T) {
+		d := validation.Describe(addressRule)
+		require.Equal(t, validation.NameStruct, d.Name)
+		require.Equal(t, reflect.TypeOf(Address{}), d.Type)
+		require.Len(t, d.Fields, 2)
+		require.Equal(t, []string{"Country"}, d.Fields[0].Path)
+		require.Equal(t, reflect.TypeOf(""), d.Fields[0].Type)
+		require.Len(t, d.Fields[0].Rules, 2)
+	})
+}
+
+func always(ctx, v interface{}) bool {
+	return true
+}
@@ -0,0 +1,276 @@
+package validation
+
+import (
+	"reflect"
+)
+
+// Embedding defines how the fields of embedded structs are named.
+type Embedding int
+
+const (
+	// EmbedNested reports the fields of an embedded struct under the name
+	// of the embedded struct, e.g. Base.ID.
+	EmbedNested Embedding = iota
+	// EmbedFlat reports the promoted fields of an embedded struct as the
+	// fields of the parent struct, e.g. ID.
+	EmbedFlat
+)
+
+// Embed sets how the fields of embedded structs are named.
+func Embed(e Embedding) Option {
+	return func(s *Schema) {
+		s.embed = e
+	}
+}
+
+// Embeddable is implemented by structs having their own validation rule.
+// The rule is requested when the schema is bound, so it might be a package
+// variable initialized after the schemas of the parent structs.
+type Embeddable interface {
+	Rule() Rule
+}
+
+// InheritRules makes the schema apply the rules of the exported embedded
+// structs implementing Embeddable after the fields provided. A struct
+// implements Embeddable only by its own Rule method, a Rule method promoted
+// from a struct embedded by it does not count. The rule of an embedded
+// struct is not applied if one of the fields provided points to it already.
+func InheritRules() Option {
+	return func(s *Schema) {
+		s.inherit = true
+	}
+}
+
+var embeddableType = reflect.TypeOf((*Embeddable)(nil)).Elem()
+
+// inherited appends the fields applying the rules of the embedded structs
+// implementing Embeddable to fields.
+func (s *Schema) inherited(fields []Field) []Field {
+	if !s.inherit {
+		return fields
+	}
+
+	full := sample(s.typ, 0).Interface()
+	targets := map[interface{}]bool{}
+	for _, f := range fields {
+		if attr, ok := call(f.Attr, full); ok && reflect.ValueOf(attr).Kind() == reflect.Ptr {
+			targets[attr] = true
+		}
+	}
+
+	tp := s.typ.Elem()
+	res := fields[:len(fields):len(fields)]
+	for i := 0; i < tp.NumField(); i++ {
+		ft := tp.Field(i)
+		if !ft.Anonymous || !ft.IsExported() {
+			continue
+		}
+
+		st, ptr := ft.Type, false
+		if st.Kind() == reflect.Ptr {
+			st, ptr = st.Elem(), true
+		}
+		if st.Kind() != reflect.Struct || !embeddable(st) {
+			continue
+		}
+
+		attr := embeddedAttr(i, ptr)
+		if targets[attr(full)] {
+			continue
+		}
+
+		e := reflect.New(st).Interface().(Embeddable)
+		res = append(res, Field{
+			Attr:  attr,
+			Rules: []Rule{embeddedRule(e, ptr)},
+		})
+	}
+	return res
+}
+
+// embeddable reports whether the struct st declares the Rule method of
+// Embeddable. The method is considered promoted if any of the structs or
+// interfaces embedded by st has it.
+func embeddable(st reflect.Type) bool {
+	if !reflect.PointerTo(st).Implements(embeddableType) {
+		return false
+	}
+	for i := 0; i < st.NumField(); i++ {
+		ft := st.Field(i)
+		if !ft.Anonymous {
+			continue
+		}
+		t := ft.Type
+		if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface {
+			t = reflect.PointerTo(t)
+		}
+		if _, ok := t.MethodByName("Rule"); ok {
+			return false
+		}
+	}
+	return true
+}
+
+func embeddedAttr(i int, ptr bool) Attr {
+	return func(v interface{}) interface{} {
+		f := reflect.ValueOf(v).Elem().Field(i)
+		if ptr {
+			return f.Interface()
+		}
+		return f.Addr().Interface()
+	}
+}
+
+// embeddedRule applies the rule of e, the structs embedded by nil pointers
+// are not validated.
+func embeddedRule(e Embeddable, ptr bool) Rule {
+	return Described(func(ctx interface{}) func(interface{}) error {
+		fn := e.Rule()(ctx)
+		if !ptr {
+			return fn
+		}
+		return func(v interface{}) error {
+			if reflect.ValueOf(v).IsNil() {
+				return nil
+			}
+			return fn(v)
+		}
+	}, func() Descriptor {
+		return Describe(e.Rule())
+	})
+}
+
+type fieldKey struct {
+	offset uintptr
+	typ    reflect.Type
+}
+
+// fieldTable maps offsets of the struct fields, including fields of nested,
+// embedded and pointed structs, to their paths. Nested fields can share the
+// offset with their parent, so the field type is the part of the key.
+// Ignored fields and their nested fields keep the names given by the
+// NameFunc, so their errors are still located.
+type fieldTable struct {
+	size  uintptr
+	names map[fieldKey]fieldName
+	ptrs  []embeddedPtr
+}
+
+// fieldName is the path of a field and whether the field, or the one it is
+// nested in, is ignored by the NameFunc.
+type fieldName struct {
+	path    []string
+	ignored bool
+}
+
+// embeddedPtr represents a struct referenced by a pointer field, embedded or
+// named. Its fields are not located within the parent struct, so they are
+// resolved through the pointer on every lookup.
+type embeddedPtr struct {
+	index []int
+	table *fieldTable
+}
+
+func newFieldTable(tp reflect.Type, name NameFunc, embed Embedding) *fieldTable {
+	return buildFieldTable(tp, name, embed, fieldName{path: []string{}}, map[reflect.Type]bool{})
+}
+
+func buildFieldTable(tp reflect.Type, name NameFunc, embed Embedding, parent fieldName, seen map[reflect.Type]bool) *fieldTable {
+	t := &fieldTable{size: tp.Size(), names: map[fieldKey]fieldName{}}
+	seen[tp] = true
+	t.add(tp, name, embed, 0, nil, parent, seen)
+	delete(seen, tp)
+	return t
+}
+
+func (t *fieldTable) add(tp reflect.Type, name NameFunc, embed Embedding, base uintptr, index []int, parent fieldName, seen map[reflect.Type]bool) {
+	for i := 0; i < tp.NumField(); i++ {
+		ft := tp.Field(i)
+
+		fpath := fieldPathOf(ft, name, embed, parent)
+
+		key := fieldKey{offset: base + ft.Offset, typ: ft.Type}
+		if _, ok := t.names[key]; !ok {
+			t.names[key] = fpath
+		}
+
+		findex := append(append([]int{}, index...), i)
+
+		switch {
+		case ft.Type.Kind() == reflect.Struct:
+			t.add(ft.Type, name, embed, base+ft.Offset, findex, fpath, seen)
+		case ft.Type.Kind() == reflect.Ptr &&
+			ft.Type.Elem().Kind() == reflect.Struct && !seen[ft.Type.Elem()]:
+
+			et := ft.Type.Elem()
+			pt := buildFieldTable(et, name, embed, fpath, seen)
+			pt.names[fieldKey{offset: 0, typ: et}] = fpath
+			t.ptrs = append(t.ptrs, embeddedPtr{index: findex, table: pt})
+		}
+	}
+}
+
+// fieldPathOf returns the name of the field ft of the struct named parent.
+// The fields ignored by the NameFunc are not flattened, and fall back to
+// their Go names if the NameFunc gives none.
+func fieldPathOf(ft reflect.StructField, name NameFunc, embed Embedding, parent fieldName) fieldName {
+	fname, ok := name(ft)
+	if fname == "" {
+		fname = ft.Name
+	}
+
+	fpath := make([]string, len(parent.path), len(parent.path)+1)
+	copy(fpath, parent.path)
+	ignored := parent.ignored || !ok
+
+	if embed == EmbedFlat && ok && ft.Anonymous && !tagged(ft, name, fname) {
+		if t := ft.Type; t.Kind() == reflect.Struct ||
+			t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+			return fieldName{path: fpath, ignored: ignored}
+		}
+	}
+
+	return fieldName{path: append(fpath, fname), ignored: ignored}
+}
+
+// tagged reports whether the name of ft is taken from its tags, i.e. the
+// name differs from the one given to the field without tags. As in
+// encoding/json, embedded structs having explicit names are not flattened.
+func tagged(ft reflect.StructField, name NameFunc, fname string) bool {
+	ft.Tag = ""
+	untagged, _ := name(ft)
+	return untagged != fname
+}
+
+// lookup gets the path of the field pointed by attr within the struct v.
+func (t *fieldTable) lookup(v, attr interface{}) ([]string, bool) {
+	fn, ok := t.find(v, attr)
+	return fn.path, ok
+}
+
+// find gets the name of the field pointed by attr within the struct v.
+func (t *fieldTable) find(v, attr interface{}) (fieldName, bool) {
+	return t.lookupAt(reflect.ValueOf(v), reflect.ValueOf(attr))
+}
+
+func (t *fieldTable) lookupAt(v, attr reflect.Value) (fieldName, bool) {
+	offset := attr.Pointer() - v.Pointer()
+	if offset <= t.size {
+		key := fieldKey{offset: offset, typ: attr.Type().Elem()}
+		if fn, ok := t.names[key]; ok {
+			return fn, true
+		}
+	}
+
+	for _, e := range t.ptrs {
+		p := v.Elem().FieldByIndex(e.index)
+		if p.IsNil() {
+			continue
+		}
+		if fn, ok := e.table.lookupAt(p, attr); ok {
+			return fn, true
+		}
+	}
+
+	return fieldName{}, false
+}
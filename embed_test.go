@@ -0,0 +1,235 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+type Account struct {
+	*Base
+	Name string `json:"name"`
+}
+
+var accountFields = []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Account).ID
+		},
+		Rules: []validation.Rule{validation.Func(stringRequired)},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Account).Name
+		},
+		Rules: []validation.Rule{validation.Func(stringRequired)},
+	},
+}
+
+type Audit struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+func (Audit) Rule() validation.Rule {
+	return auditRule
+}
+
+type Owner struct {
+	Email string `json:"email"`
+}
+
+func (*Owner) Rule() validation.Rule {
+	return ownerRule
+}
+
+type Document struct {
+	Audit
+	*Owner
+	Title string `json:"title"`
+}
+
+// documentRule is initialized before the rules of the embedded structs.
+var documentRule = validation.Struct(&Document{}, "json", []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Document).Title
+		},
+		Rules: []validation.Rule{validation.Func(stringRequired)},
+	},
+}, validation.InheritRules())
+
+var auditRule = validation.Struct(&Audit{}, "json", []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Audit).CreatedBy
+		},
+		Rules: []validation.Rule{validation.Func(stringRequired)},
+	},
+})
+
+var ownerRule = validation.Struct(&Owner{}, "json", []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Owner).Email
+		},
+		Rules: []validation.Rule{validation.Func(stringRequired)},
+	},
+})
+
+func blankField(name string) validation.StructError {
+	return validation.StructError{Field: name, Errors: []error{errors.New(eRequired)}}
+}
+
+type Revision struct {
+	Audit
+	Number int
+}
+
+type Revised struct {
+	Revision `json:"revision"`
+}
+
+type Tagged struct {
+	Base  `json:"base"`
+	Audit `json:",omitempty"`
+}
+
+func TestEmbed(t *testing.T) {
+	t.Run("FlatValue", func(t *testing.T) {
+		r := validation.Struct(&Profile{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Profile).ID
+				},
+				Rules: []validation.Rule{validation.Func(stringRequired)},
+			},
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Profile).Name
+				},
+				Rules: []validation.Rule{validation.Func(stringRequired)},
+			},
+		}, validation.Embed(validation.EmbedFlat))
+		require.Equal(t, validation.Errors{
+			blankField("id"),
+			blankField("name"),
+		}, r(nil)(&Profile{}))
+	})
+	t.Run("NestedPtr", func(t *testing.T) {
+		r := validation.Struct(&Account{}, "json", accountFields)
+		require.Equal(t, validation.Errors{
+			validation.StructError{Field: "Base", Errors: []error{blankField("id")}},
+			blankField("name"),
+		}, r(nil)(&Account{Base: &Base{}}))
+	})
+	t.Run("FlatPtr", func(t *testing.T) {
+		r := validation.Struct(&Account{}, "json", accountFields, validation.Embed(validation.EmbedFlat))
+		require.Equal(t, validation.Errors{
+			blankField("id"),
+			blankField("name"),
+		}, r(nil)(&Account{Base: &Base{}}))
+	})
+	t.Run("EmbeddableNested", func(t *testing.T) {
+		require.Equal(t, validation.Errors{
+			blankField("title"),
+			validation.StructError{Field: "Audit", Errors: []error{blankField("createdBy")}},
+			validation.StructError{Field: "Owner", Errors: []error{blankField("email")}},
+		}, documentRule(nil)(&Document{Owner: &Owner{}}))
+	})
+	t.Run("EmbeddableFlat", func(t *testing.T) {
+		r := validation.Struct(&Document{}, "json", nil,
+			validation.Embed(validation.EmbedFlat), validation.InheritRules())
+		require.Equal(t, validation.Errors{
+			blankField("createdBy"),
+			blankField("email"),
+		}, r(nil)(&Document{Owner: &Owner{}}))
+	})
+	t.Run("EmbeddableNilPtrSkipped", func(t *testing.T) {
+		require.Nil(t, documentRule(nil)(&Document{
+			Audit: Audit{CreatedBy: "user"},
+			Title: "doc",
+		}))
+	})
+	t.Run("EmbeddableDescribed", func(t *testing.T) {
+		d := validation.Describe(documentRule)
+		require.Len(t, d.Fields, 3)
+		require.Equal(t, validation.NameStruct, d.Fields[1].Rules[0].Name)
+		require.Equal(t, []string{"Audit"}, d.Fields[1].Path)
+	})
+	t.Run("NotInheritedByDefault", func(t *testing.T) {
+		r := validation.Struct(&Document{}, "json", nil)
+		require.Nil(t, r(nil)(&Document{Owner: &Owner{}}))
+	})
+	t.Run("PromotedRuleIgnored", func(t *testing.T) {
+		r := validation.Struct(&Revised{}, "json", nil, validation.InheritRules())
+		require.Nil(t, r(nil)(&Revised{}))
+	})
+	t.Run("ExplicitFieldNotDuplicated", func(t *testing.T) {
+		r := validation.Struct(&Document{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Document).Audit
+				},
+				Rules: []validation.Rule{auditRule},
+			},
+		}, validation.InheritRules())
+		require.Equal(t, validation.Errors{
+			validation.StructError{Field: "Audit", Errors: []error{blankField("createdBy")}},
+		}, r(nil)(&Document{}))
+	})
+	t.Run("TaggedNotFlattened", func(t *testing.T) {
+		r := validation.Struct(&Tagged{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Tagged).ID
+				},
+				Rules: []validation.Rule{validation.Func(stringRequired)},
+			},
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Tagged).CreatedBy
+				},
+				Rules: []validation.Rule{validation.Func(stringRequired)},
+			},
+		}, validation.Embed(validation.EmbedFlat))
+		require.Equal(t, validation.Errors{
+			validation.StructError{Field: "base", Errors: []error{blankField("id")}},
+			blankField("createdBy"),
+		}, r(nil)(&Tagged{}))
+	})
+	t.Run("VerifyInherited", func(t *testing.T) {
+		_, err := validation.Compile(&Document{}, "json", nil,
+			validation.InheritRules(), validation.Verify())
+		require.Nil(t, err)
+
+		var ve *validation.VerifyError
+		_, err = validation.Compile(&Broken{}, "json", nil,
+			validation.InheritRules(), validation.Verify())
+		require.ErrorAs(t, err, &ve)
+		require.Len(t, ve.Errors, 1)
+		require.Equal(t, "Broken.Count", ve.Errors[0].(validation.Panic).Loc.Path.String())
+	})
+}
+
+type Broken struct {
+	Broken2 `json:"Broken"`
+}
+
+type Broken2 struct {
+	Count int
+}
+
+func (*Broken2) Rule() validation.Rule {
+	return validation.Struct(&Broken2{}, "", []validation.Field{
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*Broken2).Count
+			},
+			Rules: []validation.Rule{rule.StrRequired("blank")},
+		},
+	})
+}
@@ -5,28 +5,64 @@ import (
 	"strings"
 )
 
-// Panic represents an internal error of schema package.
+// Panic represents an internal error of schema package, e.g. a rule applied
+// to a value of unexpected type or a Go panic recovered during validation.
+// The Loc locates the error if known, it is a pointer to keep Panic
+// comparable.
 type Panic struct {
 	Err error
+	Loc *Location
 }
 
 // Error rerurns string representation of the internal error.
 func (e Panic) Error() string {
-	return e.Err.Error()
+	if e.Loc == nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v (%s)", e.Err, e.Loc)
+}
+
+// Unwrap returns the internal error.
+func (e Panic) Unwrap() error {
+	return e.Err
 }
 
 // Params represents validation error parameters.
 type Params map[string]interface{}
 
-// Error represents a validation error
+// Error represents a validation error. The Code is a stable identifier of
+// the error, e.g. "str.min_len", clients should match it instead of the
+// Message. The Cause is an optional underlying error, e.g. a parse error.
 type Error struct {
+	Code    string
 	Message string
 	Params  Params
+	Cause   error
+}
+
+// Code creates a sentinel error matching validation errors with the code
+// provided, e.g.
+//
+//	errors.Is(err, validation.Code("str.min_len"))
+func Code(code string) error {
+	return Error{Code: code}
 }
 
-// Error gets string representation of a validation error.
+// Error gets string representation of a validation error. Placeholders of
+// the message are replaced by the error params, see Interpolate.
 func (e Error) Error() string {
-	return e.Message
+	return Interpolate(e.Message, e.Params)
+}
+
+// Unwrap returns the cause of the error.
+func (e Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is a validation error having the same code.
+func (e Error) Is(target error) bool {
+	t, ok := target.(Error)
+	return ok && t.Code != "" && t.Code == e.Code
 }
 
 // Errors represents errors collection.
@@ -41,6 +77,11 @@ func (e Errors) Error() string {
 	return strings.Join(errors, ", ")
 }
 
+// Unwrap returns the errors of the collection.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
 // StructError represents a struct field validation error.
 type StructError struct {
 	Field  string
@@ -52,6 +93,11 @@ func (e StructError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Errors.Error())
 }
 
+// Unwrap returns the errors of the field.
+func (e StructError) Unwrap() []error {
+	return e.Errors
+}
+
 // SliceError represents a slice item validation error.
 type SliceError struct {
 	Index  int
@@ -63,7 +109,45 @@ func (e SliceError) Error() string {
 	return fmt.Sprintf("%d: %s", e.Index, e.Errors.Error())
 }
 
+// Unwrap returns the errors of the item.
+func (e SliceError) Unwrap() []error {
+	return e.Errors
+}
+
+// MapError represents a map entry validation error.
+type MapError struct {
+	Key    interface{}
+	Errors Errors
+}
+
+// Error returns string representation of a MapError.
+func (e MapError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Key, e.Errors.Error())
+}
+
+// Unwrap returns the errors of the entry.
+func (e MapError) Unwrap() []error {
+	return e.Errors
+}
+
 // Errorf creates validation errros from a single error.
 func Errorf(format string, args ...interface{}) Errors {
 	return Errors([]error{fmt.Errorf(format, args...)})
 }
+
+// VerifyError represents the schema mistakes found by Verify, each of them is
+// a Panic located at the field. It is wrapped into Panic by pointer, so the
+// Panic stays comparable.
+type VerifyError struct {
+	Errors Errors
+}
+
+// Error returns string representation of a VerifyError.
+func (e *VerifyError) Error() string {
+	return e.Errors.Error()
+}
+
+// Unwrap returns the mistakes found.
+func (e *VerifyError) Unwrap() []error {
+	return e.Errors
+}
@@ -1,7 +1,9 @@
 package validation_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/vbogretsov/go-validation"
@@ -14,6 +16,14 @@ func TestPanic(t *testing.T) {
 	if err.Error() != msg {
 		t.Errorf("expected '%s' but was '%s'", msg, err.Error())
 	}
+
+	located := validation.Locate(err, nil, "", validation.AtField("name"))
+	if located == error(err) || !errors.Is(located, err.Err) {
+		t.Errorf("unexpected located panic %v", located)
+	}
+	if !errors.Is(fmt.Errorf("wrapped: %w", err), err) {
+		t.Error("expected Panic to be comparable")
+	}
 }
 
 func TestStructError(t *testing.T) {
@@ -43,3 +53,62 @@ func TestSliceError(t *testing.T) {
 		t.Errorf("expected '%s' but got '%s", exp, act)
 	}
 }
+
+func TestMapError(t *testing.T) {
+	e := validation.MapError{
+		Key:    "env",
+		Errors: []error{errors.New("1"), errors.New("2")},
+	}
+
+	exp := "env: 1, 2"
+	act := e.Error()
+
+	if exp != act {
+		t.Errorf("expected '%s' but got '%s", exp, act)
+	}
+}
+
+func TestErrorsUnwrap(t *testing.T) {
+	cause := errors.New("parse error")
+	leaf := validation.Error{Code: "num.parse", Message: "not a number", Cause: cause}
+	err := error(validation.Errors{
+		validation.StructError{
+			Field: "items",
+			Errors: validation.Errors{
+				validation.SliceError{
+					Index: 1,
+					Errors: validation.Errors{
+						validation.MapError{Key: "qty", Errors: validation.Errors{leaf}},
+					},
+				},
+			},
+		},
+	})
+
+	var e validation.Error
+	if !errors.As(err, &e) || e.Code != "num.parse" {
+		t.Errorf("expected validation.Error but got %v", e)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected the cause to be found")
+	}
+	if !errors.Is(err, validation.Code("num.parse")) {
+		t.Error("expected the error to match the code")
+	}
+	if errors.Is(err, validation.Code("num.min")) {
+		t.Error("expected the error not to match other code")
+	}
+	if errors.Is(validation.Error{Message: "a"}, validation.Error{Message: "a"}) {
+		t.Error("expected errors without code not to match")
+	}
+}
+
+func TestFatalUnwrap(t *testing.T) {
+	cause := errors.New("test")
+	if !errors.Is(validation.Panic{Err: cause}, cause) {
+		t.Error("expected Panic to unwrap its error")
+	}
+	if !errors.Is(validation.Canceled{Err: context.Canceled}, context.Canceled) {
+		t.Error("expected Canceled to unwrap its error")
+	}
+}
@@ -0,0 +1,270 @@
+// Package i18n localizes validation error messages. Messages are looked up
+// in a Catalog by the error code and then by the error message, which allows
+// rules to take message ids instead of texts.
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/vbogretsov/go-validation"
+	jsonerr "github.com/vbogretsov/go-validation/json"
+)
+
+// Message represents a localized message. Forms holds the plural forms by
+// the plural category, Count is the name of the error parameter selecting the
+// form. The first numeric parameter selects the form if Count is empty.
+type Message struct {
+	Text  string
+	Forms map[string]string
+	Count string
+}
+
+// UnmarshalJSON decodes a message either from a string or from an object
+// holding the plural forms and optionally the "count" parameter name, e.g.
+//
+//	{"count": "minLen", "one": "...", "other": "..."}
+func (m *Message) UnmarshalJSON(b []byte) error {
+	var text string
+	if err := json.Unmarshal(b, &text); err == nil {
+		*m = Message{Text: text}
+		return nil
+	}
+
+	var forms map[string]string
+	if err := json.Unmarshal(b, &forms); err != nil {
+		return err
+	}
+
+	*m = Message{Count: forms["count"], Forms: forms}
+	delete(m.Forms, "count")
+
+	return nil
+}
+
+// text returns the message text for the error parameters provided.
+func (m Message) text(locale string, params validation.Params) string {
+	if len(m.Forms) == 0 {
+		return m.Text
+	}
+
+	if n, ok := count(params, m.Count); ok {
+		if s, ok := m.Forms[pluralRule(locale)(n)]; ok {
+			return s
+		}
+	}
+
+	if s, ok := m.Forms[Other]; ok {
+		return s
+	}
+	return m.Text
+}
+
+func count(params validation.Params, name string) (float64, bool) {
+	if name != "" {
+		return number(params[name])
+	}
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if n, ok := number(params[k]); ok {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+func number(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// Catalog represents a collection of messages per locale. A Catalog should
+// be filled before it is used concurrently.
+type Catalog struct {
+	def       string
+	fallbacks map[string][]string
+	messages  map[string]map[string]Message
+}
+
+// NewCatalog creates new empty catalog. The locale def is used if no other
+// locale provides a message.
+func NewCatalog(def string) *Catalog {
+	return &Catalog{
+		def:       normalize(def),
+		fallbacks: map[string][]string{},
+		messages:  map[string]map[string]Message{},
+	}
+}
+
+// Add adds the messages of the locale provided, the keys are error codes or
+// message ids.
+func (c *Catalog) Add(locale string, messages map[string]Message) {
+	locale = normalize(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = map[string]Message{}
+	}
+	for k, m := range messages {
+		c.messages[locale][k] = m
+	}
+}
+
+// Load loads the messages of the locale provided from JSON.
+func (c *Catalog) Load(locale string, r io.Reader) error {
+	var messages map[string]Message
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return fmt.Errorf("i18n: load %s: %w", locale, err)
+	}
+	c.Add(locale, messages)
+	return nil
+}
+
+// LoadFS loads messages from the files <locale>.json of the directory dir,
+// e.g. from an embed.FS.
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		err = c.Load(strings.TrimSuffix(path.Base(name), ".json"), f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Fallback sets the locales looked up if the locale provided misses a
+// message. The language of a regional locale, e.g. "pt" for "pt-BR", and the
+// default locale are looked up in any case.
+func (c *Catalog) Fallback(locale string, fallbacks ...string) {
+	locales := make([]string, len(fallbacks))
+	for i, f := range fallbacks {
+		locales[i] = normalize(f)
+	}
+	c.fallbacks[normalize(locale)] = locales
+}
+
+// Locales returns the locales of the catalog.
+func (c *Catalog) Locales() []string {
+	locales := make([]string, 0, len(c.messages))
+	for l := range c.messages {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Match returns the catalog locale best matching the Accept-Language value
+// provided or the default locale.
+func (c *Catalog) Match(acceptLanguage string) string {
+	for _, tag := range ParseAcceptLanguage(acceptLanguage) {
+		tag = normalize(tag)
+		if _, ok := c.messages[tag]; ok {
+			return tag
+		}
+		if _, ok := c.messages[base(tag)]; ok {
+			return base(tag)
+		}
+	}
+	return c.def
+}
+
+// chain returns the locales looked up for the locale provided.
+func (c *Catalog) chain(locale string) []string {
+	var locales []string
+	seen := map[string]bool{}
+
+	var add func(string)
+	add = func(l string) {
+		if l == "" || seen[l] {
+			return
+		}
+		seen[l] = true
+		locales = append(locales, l)
+		for _, f := range c.fallbacks[l] {
+			add(f)
+		}
+	}
+
+	locale = normalize(locale)
+	add(locale)
+	add(base(locale))
+	add(c.def)
+
+	return locales
+}
+
+// Message returns the localized message of the error e. It returns the
+// error message if the catalog does not have the message.
+func (c *Catalog) Message(locale string, e validation.Error) string {
+	for _, l := range c.chain(locale) {
+		msgs := c.messages[l]
+		if m, ok := msgs[e.Code]; ok && e.Code != "" {
+			return m.text(l, e.Params)
+		}
+		if m, ok := msgs[e.Message]; ok {
+			return m.text(l, e.Params)
+		}
+	}
+	return e.Message
+}
+
+// Localize replaces messages of the validation errors within err by their
+// localized messages. Other errors are returned as is.
+func (c *Catalog) Localize(locale string, err error) error {
+	return validation.Map(err, func(_ validation.Path, leaf error) error {
+		if e, ok := leaf.(validation.Error); ok {
+			e.Message = c.Message(locale, e)
+			return e
+		}
+		return leaf
+	})
+}
+
+// Formatter creates a json.Formatter localizing messages. Placeholders of
+// the messages are replaced by the error params.
+func (c *Catalog) Formatter(locale string) jsonerr.Formatter {
+	return func(e validation.Error) string {
+		return validation.Interpolate(c.Message(locale, e), e.Params)
+	}
+}
+
+// Validate validates v against the rule and localizes the errors according to
+// the locale of ctx, see WithLocale.
+func (c *Catalog) Validate(ctx context.Context, rule validation.Rule, v interface{}) error {
+	err := validation.Validate(ctx, rule, v)
+	if err == nil || validation.Fatal(err) {
+		return err
+	}
+	return c.Localize(LocaleOf(ctx), err)
+}
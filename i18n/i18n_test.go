@@ -0,0 +1,139 @@
+package i18n_test
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/i18n"
+	jsonerr "github.com/vbogretsov/go-validation/json"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+type User struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+var userRule = validation.Struct(&User{}, "json", []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Email
+		},
+		Rules: []validation.Rule{
+			rule.StrRequired("cannot be blank"),
+			rule.StrEmail("ErrEmail"),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Password
+		},
+		Rules: []validation.Rule{rule.StrMinLen(3, "too short")},
+	},
+})
+
+func newCatalog(t *testing.T) *i18n.Catalog {
+	c := i18n.NewCatalog("en")
+	require.NoError(t, c.LoadFS(testdata, "testdata"))
+	return c
+}
+
+func minLenError(n int) validation.Error {
+	return validation.Error{
+		Code:    rule.NameStrMinLen,
+		Message: "too short",
+		Params:  validation.Params{rule.ParamStrMinLen: n},
+	}
+}
+
+func TestCatalogLoad(t *testing.T) {
+	c := newCatalog(t)
+	require.Equal(t, []string{"en", "pt", "pt-BR", "ru"}, c.Locales())
+
+	err := c.Load("de", strings.NewReader(`{"str.required": 1}`))
+	require.Error(t, err)
+}
+
+func TestCatalogMessage(t *testing.T) {
+	c := newCatalog(t)
+	blank := validation.Error{Code: rule.NameStrRequired, Message: "cannot be blank"}
+	email := validation.Error{Code: rule.NameStrEmail, Message: "ErrEmail"}
+
+	t.Run("ByCode", func(t *testing.T) {
+		require.Equal(t, "не может быть пустым", c.Message("ru", blank))
+	})
+	t.Run("ByMessageID", func(t *testing.T) {
+		require.Equal(t, "invalid email", c.Message("en", email))
+	})
+	t.Run("RegionalLocale", func(t *testing.T) {
+		require.Equal(t, "e-mail inválido", c.Message("pt-BR", email))
+		require.Equal(t, "não pode estar em branco", c.Message("pt-BR", blank))
+		require.Equal(t, "não pode estar em branco", c.Message("pt_br", blank))
+	})
+	t.Run("DefaultLocale", func(t *testing.T) {
+		require.Equal(t, "invalid email", c.Message("ru", email))
+		require.Equal(t, "invalid email", c.Message("", email))
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		c.Fallback("uk", "ru")
+		require.Equal(t, "не может быть пустым", c.Message("uk", blank))
+	})
+	t.Run("MessageIfMissing", func(t *testing.T) {
+		e := validation.Error{Code: "custom", Message: "custom error"}
+		require.Equal(t, "custom error", c.Message("ru", e))
+	})
+	t.Run("Plural", func(t *testing.T) {
+		require.Equal(t, "should have at least one character", c.Message("en", minLenError(1)))
+		require.Equal(t, "should have at least {minLen} characters", c.Message("en", minLenError(5)))
+		require.Equal(t, "должно содержать хотя бы один символ", c.Message("ru", minLenError(21)))
+		require.Equal(t, "должно содержать несколько символов", c.Message("ru", minLenError(3)))
+		require.Equal(t, "должно содержать много символов", c.Message("ru", minLenError(11)))
+	})
+}
+
+func TestCatalogMatch(t *testing.T) {
+	c := newCatalog(t)
+	require.Equal(t, "ru", c.Match("ru-RU,ru;q=0.9,en;q=0.8"))
+	require.Equal(t, "pt-BR", c.Match("de;q=0.9, pt-BR"))
+	require.Equal(t, "en", c.Match("de, fr;q=0.5"))
+	require.Equal(t, "en", c.Match(""))
+}
+
+func TestCatalogValidate(t *testing.T) {
+	c := newCatalog(t)
+	ctx := i18n.WithLocale(context.Background(), c.Match("ru"))
+
+	err := c.Validate(ctx, userRule, &User{Password: "a"})
+	require.Equal(t, "email: не может быть пустым, invalid email, password: должно содержать несколько символов", err.Error())
+
+	b, jerr := json.Marshal(jsonerr.New(err.(validation.Errors), jsonerr.DefaultFormatter, jsonerr.DefaultJoiner))
+	require.NoError(t, jerr)
+	require.Contains(t, string(b), `"error":"не может быть пустым"`)
+
+	require.Nil(t, c.Validate(ctx, userRule, &User{Email: "user@mail.com", Password: "abc"}))
+}
+
+func TestCatalogFormatter(t *testing.T) {
+	c := newCatalog(t)
+	errs := validation.Errors{
+		validation.StructError{Field: "password", Errors: validation.Errors{minLenError(2)}},
+	}
+
+	b, err := json.Marshal(jsonerr.New(errs, c.Formatter("ru"), jsonerr.DefaultJoiner))
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"error":"должно содержать несколько символов"`)
+}
+
+func TestCatalogFormatterInterpolates(t *testing.T) {
+	c := newCatalog(t)
+	require.Equal(t, "should have at least 5 characters", c.Formatter("en")(minLenError(5)))
+}
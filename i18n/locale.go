@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+var localeKey = validation.NewKey[string]("locale")
+
+// WithLocale returns a copy of ctx holding the locale of the validation run.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return validation.WithValue(ctx, localeKey, locale)
+}
+
+// LocaleOf returns the locale of the validation run or an empty string.
+func LocaleOf(ctx interface{}) string {
+	locale, _ := validation.Value(ctx, localeKey)
+	return locale
+}
+
+// ParseAcceptLanguage parses the value of an Accept-Language header. It
+// returns the languages ordered by their quality, the wildcard is skipped.
+func ParseAcceptLanguage(s string) []string {
+	type lang struct {
+		tag string
+		q   float64
+	}
+
+	var langs []lang
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			langs = append(langs, lang{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].q > langs[j].q
+	})
+
+	tags := make([]string, len(langs))
+	for i, l := range langs {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// base returns the language of the locale, e.g. "pt" for "pt-BR".
+func base(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+// normalize normalizes a locale, e.g. "pt_br" becomes "pt-BR".
+func normalize(locale string) string {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	parts := strings.Split(locale, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) == 2 {
+			parts[i] = strings.ToUpper(parts[i])
+		}
+	}
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,26 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation/i18n"
+)
+
+func TestLocale(t *testing.T) {
+	require.Equal(t, "", i18n.LocaleOf(context.Background()))
+	require.Equal(t, "", i18n.LocaleOf(nil))
+	require.Equal(t, "ru", i18n.LocaleOf(i18n.WithLocale(context.Background(), "ru")))
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	require.Equal(t,
+		[]string{"fr-CH", "fr", "en", "de"},
+		i18n.ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5"))
+	require.Equal(t,
+		[]string{"en", "de"},
+		i18n.ParseAcceptLanguage("de;q=0.5, en, ru;q=0"))
+	require.Empty(t, i18n.ParseAcceptLanguage(""))
+}
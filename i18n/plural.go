@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"math"
+	"strings"
+)
+
+// Plural categories of CLDR.
+const (
+	Zero  = "zero"
+	One   = "one"
+	Two   = "two"
+	Few   = "few"
+	Many  = "many"
+	Other = "other"
+)
+
+// PluralRule returns the plural category of the number n.
+type PluralRule func(n float64) string
+
+// PluralRules maps languages to their plural rules. Languages not listed use
+// the English rule.
+var PluralRules = map[string]PluralRule{
+	"en": pluralOne,
+	"de": pluralOne,
+	"es": pluralOne,
+	"it": pluralOne,
+	"nl": pluralOne,
+	"fr": pluralFrench,
+	"pt": pluralFrench,
+	"ru": pluralSlavic,
+	"uk": pluralSlavic,
+	"pl": pluralPolish,
+	"ja": pluralOther,
+	"ko": pluralOther,
+	"zh": pluralOther,
+}
+
+func pluralRule(locale string) PluralRule {
+	lang := strings.ToLower(base(locale))
+	if r, ok := PluralRules[lang]; ok {
+		return r
+	}
+	return pluralOne
+}
+
+func integer(n float64) (int64, bool) {
+	if n != math.Trunc(n) {
+		return 0, false
+	}
+	return int64(math.Abs(n)), true
+}
+
+func pluralOther(float64) string {
+	return Other
+}
+
+func pluralOne(n float64) string {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+func pluralFrench(n float64) string {
+	if i, ok := integer(n); ok && i <= 1 {
+		return One
+	}
+	return Other
+}
+
+func pluralSlavic(n float64) string {
+	i, ok := integer(n)
+	if !ok {
+		return Other
+	}
+	switch {
+	case i%10 == 1 && i%100 != 11:
+		return One
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return Few
+	default:
+		return Many
+	}
+}
+
+func pluralPolish(n float64) string {
+	i, ok := integer(n)
+	if !ok {
+		return Other
+	}
+	switch {
+	case i == 1:
+		return One
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return Few
+	default:
+		return Many
+	}
+}
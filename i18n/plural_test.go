@@ -0,0 +1,26 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation/i18n"
+)
+
+func TestPluralRules(t *testing.T) {
+	cases := map[string]map[float64]string{
+		"en": {0: i18n.Other, 1: i18n.One, 2: i18n.Other, 1.5: i18n.Other},
+		"fr": {0: i18n.One, 1: i18n.One, 2: i18n.Other},
+		"ru": {1: i18n.One, 2: i18n.Few, 5: i18n.Many, 11: i18n.Many, 22: i18n.Few, 1.5: i18n.Other},
+		"pl": {1: i18n.One, 3: i18n.Few, 21: i18n.Many, 13: i18n.Many},
+		"ja": {1: i18n.Other},
+	}
+
+	for lang, forms := range cases {
+		rule := i18n.PluralRules[lang]
+		for n, form := range forms {
+			require.Equal(t, form, rule(n), "%s %v", lang, n)
+		}
+	}
+}
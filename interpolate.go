@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Interpolate replaces the placeholders {name} in msg by the values of the
+// params. Placeholders of missing params are kept as is.
+func Interpolate(msg string, params Params) string {
+	if len(params) == 0 || !strings.Contains(msg, "{") {
+		return msg
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(msg, '{')
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(msg[i:], '}')
+		if j < 0 {
+			break
+		}
+		j += i
+
+		name := msg[i+1 : j]
+		if v, ok := params[name]; ok && ident(name) {
+			b.WriteString(msg[:i])
+			fmt.Fprint(&b, v)
+			msg = msg[j+1:]
+		} else {
+			b.WriteString(msg[:i+1])
+			msg = msg[i+1:]
+		}
+	}
+	b.WriteString(msg)
+
+	return b.String()
+}
+
+func ident(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i > 0 && c >= '0' && c <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+var templates sync.Map
+
+// Render renders msg as a text/template with the params as data, e.g.
+// "{{.minLen}}". Parsed templates are cached by the message.
+func Render(msg string, params Params) (string, error) {
+	if !strings.Contains(msg, "{{") {
+		return msg, nil
+	}
+
+	t, ok := templates.Load(msg)
+	if !ok {
+		parsed, err := template.New("").Option("missingkey=zero").Parse(msg)
+		if err != nil {
+			return "", err
+		}
+		t, _ = templates.LoadOrStore(msg, parsed)
+	}
+
+	var b strings.Builder
+	if err := t.(*template.Template).Execute(&b, map[string]interface{}(params)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,57 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+func TestInterpolate(t *testing.T) {
+	params := validation.Params{"min": 2, "max": 8, "field": "password"}
+
+	cases := map[string]string{
+		"plain":                   "plain",
+		"{min}":                   "2",
+		"between {min} and {max}": "between 2 and 8",
+		"{field} is required":     "password is required",
+		"{missing} and {min}":     "{missing} and 2",
+		"{not valid} {}":          "{not valid} {}",
+		"unclosed {min":           "unclosed {min",
+		"{{min}}":                 "{2}",
+		"json {\"min\": {min}}":   "json {\"min\": 2}",
+	}
+
+	for msg, exp := range cases {
+		require.Equal(t, exp, validation.Interpolate(msg, params), msg)
+	}
+	require.Equal(t, "{min}", validation.Interpolate("{min}", nil))
+}
+
+func TestRender(t *testing.T) {
+	params := validation.Params{"min": 2, "max": 8}
+
+	s, err := validation.Render("between {{.min}} and {{.max}}", params)
+	require.NoError(t, err)
+	require.Equal(t, "between 2 and 8", s)
+
+	s, err = validation.Render(`{{if eq .min 1}}one{{else}}{{.min}}{{end}} chars`, params)
+	require.NoError(t, err)
+	require.Equal(t, "2 chars", s)
+
+	s, err = validation.Render("plain {min}", params)
+	require.NoError(t, err)
+	require.Equal(t, "plain {min}", s)
+
+	_, err = validation.Render("{{.min", params)
+	require.Error(t, err)
+}
+
+func TestErrorInterpolates(t *testing.T) {
+	e := validation.Error{
+		Message: "should have at least {minLen} characters",
+		Params:  validation.Params{"minLen": 5},
+	}
+	require.Equal(t, "should have at least 5 characters", e.Error())
+}
@@ -9,6 +9,7 @@ import (
 
 type jsonError struct {
 	Path   string                 `json:"path,omitempty"`
+	Code   string                 `json:"code,omitempty"`
 	Error  string                 `json:"error"`
 	Params map[string]interface{} `json:"params,omitempty"`
 }
@@ -21,11 +22,29 @@ func DefaultFormatter(e validation.Error) string {
 	return e.Message
 }
 
+// InterpolateFormatter replaces the placeholders {name} of the message by the
+// error params.
+func InterpolateFormatter(e validation.Error) string {
+	return validation.Interpolate(e.Message, e.Params)
+}
+
+// TemplateFormatter renders the message as a text/template with the error
+// params as data. The message is returned as is if it is not a valid
+// template.
+func TemplateFormatter(e validation.Error) string {
+	s, err := validation.Render(e.Message, e.Params)
+	if err != nil {
+		return e.Message
+	}
+	return s
+}
+
 // Joiner defines interface for building path to an item in the validation
 // errors tree.
 type Joiner interface {
 	Struct(base, child string) string
 	Slice(base string, index int) string
+	Map(base string, key interface{}) string
 }
 
 type joiner struct{}
@@ -38,6 +57,13 @@ func (joiner) Slice(base string, index int) string {
 	return fmt.Sprintf("%s[%d]", base, index)
 }
 
+func (joiner) Map(base string, key interface{}) string {
+	if s, ok := key.(string); ok {
+		return fmt.Sprintf("%s[%q]", base, s)
+	}
+	return fmt.Sprintf("%s[%v]", base, key)
+}
+
 // DefaultJoiner if the default implementation of the PathBuilder interface.
 var DefaultJoiner = joiner{}
 
@@ -59,47 +85,40 @@ func New(errors validation.Errors, formatter Formatter, joiner Joiner) json.Mars
 
 // MarshalJSON serializes validation errors into JSON.
 func (m *marshaler) MarshalJSON() ([]byte, error) {
-	path := ""
 	errs := []jsonError{}
 
-	for _, e := range m.errors {
-		m.marshal(e, path, &errs)
-	}
+	validation.Walk(m.errors, func(path validation.Path, err error) error {
+		p := m.path(path)
+		if e, ok := err.(validation.Error); ok {
+			errs = append(errs, jsonError{
+				Path:   p,
+				Code:   e.Code,
+				Error:  m.formatter(e),
+				Params: e.Params,
+			})
+		} else {
+			errs = append(errs, jsonError{
+				Path:  p,
+				Error: err.Error(),
+			})
+		}
+		return nil
+	})
 
 	return json.Marshal(errs)
 }
 
-func (m *marshaler) marshal(er error, path string, errs *[]jsonError) {
-	switch x := er.(type) {
-	case validation.Errors:
-		for _, e := range []error(x) {
-			m.marshal(e, path, errs)
-		}
-	case validation.StructError:
-		v := validation.StructError(x)
-		p := m.joiner.Struct(path, v.Field)
-
-		for _, e := range []error(v.Errors) {
-			m.marshal(e, p, errs)
-		}
-	case validation.SliceError:
-		v := validation.SliceError(x)
-		p := m.joiner.Slice(path, v.Index)
-
-		for _, e := range []error(v.Errors) {
-			m.marshal(e, p, errs)
+func (m *marshaler) path(path validation.Path) string {
+	p := ""
+	for _, s := range path {
+		switch s.Kind {
+		case validation.IndexSegment:
+			p = m.joiner.Slice(p, s.Index)
+		case validation.KeySegment:
+			p = m.joiner.Map(p, s.Key)
+		default:
+			p = m.joiner.Struct(p, s.Field)
 		}
-	case validation.Error:
-		e := er.(validation.Error)
-		*errs = append(*errs, jsonError{
-			Path:   path,
-			Error:  m.formatter(e),
-			Params: e.Params,
-		})
-	default:
-		*errs = append(*errs, jsonError{
-			Path:  path,
-			Error: er.Error(),
-		})
 	}
+	return p
 }
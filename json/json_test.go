@@ -24,6 +24,7 @@ const (
 type jsonError struct {
 	Error string `json:"error"`
 	Path  string `json:"path,omitempty"`
+	Code  string `json:"code,omitempty"`
 }
 
 type fixture struct {
@@ -54,6 +55,50 @@ func (fx fixture) check() error {
 }
 
 var fixtures = []fixture{
+	{
+		err: validation.Errors([]error{
+			validation.StructError{
+				Field: "password",
+				Errors: []error{
+					validation.Error{Code: "str.min_len", Message: ePasswordShort},
+				},
+			},
+		}),
+		rep: []jsonError{
+			{
+				Error: ePasswordShort,
+				Path:  ".password",
+				Code:  "str.min_len",
+			},
+		},
+	},
+	{
+		err: validation.Errors([]error{
+			validation.StructError{
+				Field: "labels",
+				Errors: []error{
+					validation.MapError{
+						Key:    "env",
+						Errors: []error{errors.New(eLettersOnly)},
+					},
+					validation.MapError{
+						Key:    1,
+						Errors: []error{errors.New(eBlank)},
+					},
+				},
+			},
+		}),
+		rep: []jsonError{
+			{
+				Error: eLettersOnly,
+				Path:  `.labels["env"]`,
+			},
+			{
+				Error: eBlank,
+				Path:  ".labels[1]",
+			},
+		},
+	},
 	{
 		err: validation.Errors([]error{
 			validation.StructError{
@@ -188,3 +233,23 @@ func TestMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatters(t *testing.T) {
+	e := validation.Error{
+		Message: "between {min} and {max}",
+		Params:  validation.Params{"min": 1, "max": 5},
+	}
+	if s := jsonerr.InterpolateFormatter(e); s != "between 1 and 5" {
+		t.Errorf("unexpected message %q", s)
+	}
+
+	e.Message = "between {{.min}} and {{.max}}"
+	if s := jsonerr.TemplateFormatter(e); s != "between 1 and 5" {
+		t.Errorf("unexpected message %q", s)
+	}
+
+	e.Message = "between {{.min"
+	if s := jsonerr.TemplateFormatter(e); s != e.Message {
+		t.Errorf("unexpected message %q", s)
+	}
+}
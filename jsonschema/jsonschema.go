@@ -0,0 +1,308 @@
+// Package jsonschema exports validation rules as JSON Schema (draft 2020-12)
+// documents.
+package jsonschema
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+// Draft is the JSON Schema dialect of the documents generated.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema represents a JSON Schema document.
+type Schema map[string]interface{}
+
+// Extension maps a rule unknown to the package, e.g. a custom rule, to the
+// keywords of the schema s of the value validated.
+type Extension func(d validation.Descriptor, s Schema)
+
+// DefaultExtension ignores unknown rules.
+func DefaultExtension(validation.Descriptor, Schema) {}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type generator struct {
+	ext Extension
+}
+
+// New creates JSON Schema of the values validated by the rule, which is
+// usually a validation.Struct rule. Unknown rules are passed to ext, nil ext
+// ignores them.
+func New(r validation.Rule, ext Extension) Schema {
+	if ext == nil {
+		ext = DefaultExtension
+	}
+	g := generator{ext: ext}
+	d := validation.Describe(r)
+
+	s := g.schema(d.Type, []validation.Descriptor{d})
+	s["$schema"] = Draft
+
+	return s
+}
+
+func (g generator) schema(t reflect.Type, ds []validation.Descriptor) Schema {
+	s := typeSchema(t)
+	for _, d := range ds {
+		g.apply(s, indirect(t), d)
+	}
+	return s
+}
+
+func (g generator) apply(s Schema, t reflect.Type, d validation.Descriptor) {
+	switch d.Name {
+	case validation.NameRules, rule.NameOptional:
+		for _, c := range d.Rules {
+			g.apply(s, t, c)
+		}
+	case validation.NameStruct:
+		g.object(s, d)
+	case rule.NameRequired, rule.NameNotNil:
+		// Required fields are listed by the parent object.
+	case rule.NameStrRequired:
+		if _, ok := s["minLength"]; !ok {
+			s["minLength"] = 1
+		}
+	case rule.NameStrLen:
+		s["minLength"] = d.Params[rule.ParamStrMinLen]
+		s["maxLength"] = d.Params[rule.ParamStrMaxLen]
+	case rule.NameStrMinLen:
+		s["minLength"] = d.Params[rule.ParamStrMinLen]
+	case rule.NameStrMaxLen:
+		s["maxLength"] = d.Params[rule.ParamStrMaxLen]
+	case rule.NameStrMatch:
+		s["pattern"] = d.Params[rule.ParamStrPattern]
+	case rule.NameStrEmail:
+		s["format"] = "email"
+	case rule.NameStrIPv4:
+		s["format"] = "ipv4"
+	case rule.NameStrIPv6:
+		s["format"] = "ipv6"
+	case rule.NameStrURL:
+		s["format"] = "uri"
+	case rule.NameNumMin:
+		bound(s, "minimum", d.Params[rule.ParamNumMin])
+	case rule.NameNumMax:
+		bound(s, "maximum", d.Params[rule.ParamNumMax])
+	case rule.NameNumBetween:
+		bound(s, "minimum", d.Params[rule.ParamNumMin])
+		bound(s, "maximum", d.Params[rule.ParamNumMax])
+	case rule.NameIn:
+		s["enum"] = d.Params[rule.ParamInSupported]
+	case rule.NameSliceLen:
+		s["minItems"] = d.Params[rule.ParamSliceMinLen]
+		s["maxItems"] = d.Params[rule.ParamSliceMaxLen]
+	case rule.NameSliceMinLen:
+		s["minItems"] = d.Params[rule.ParamSliceMinLen]
+	case rule.NameSliceMaxLen:
+		s["maxItems"] = d.Params[rule.ParamSliceMaxLen]
+	case rule.NameSliceUnique:
+		s["uniqueItems"] = true
+	case rule.NameSliceEach:
+		g.child(s, "items", elem(t), d.Items)
+	case rule.NameMapLen:
+		s["minProperties"] = d.Params[rule.ParamMapMinLen]
+		s["maxProperties"] = d.Params[rule.ParamMapMaxLen]
+	case rule.NameMapMinLen:
+		s["minProperties"] = d.Params[rule.ParamMapMinLen]
+	case rule.NameMapMaxLen:
+		s["maxProperties"] = d.Params[rule.ParamMapMaxLen]
+	case rule.NameMapEach:
+		if len(d.Keys) > 0 {
+			g.child(s, "propertyNames", key(t), d.Keys)
+		}
+		if len(d.Items) > 0 {
+			g.child(s, "additionalProperties", elem(t), d.Items)
+		}
+	case rule.NameMapRequiredKeys:
+		for _, k := range d.Params[rule.ParamMapKeys].([]interface{}) {
+			if name, ok := k.(string); ok {
+				require(s, name)
+			}
+		}
+	case rule.NameMapForbiddenKeys:
+		for _, k := range d.Params[rule.ParamMapKeys].([]interface{}) {
+			if name, ok := k.(string); ok {
+				properties(s)[name] = false
+			}
+		}
+	default:
+		g.ext(d, s)
+	}
+}
+
+// object adds the struct fields to the object schema s.
+func (g generator) object(s Schema, d validation.Descriptor) {
+	for _, f := range d.Fields {
+		if len(f.Path) == 0 || f.Ignored {
+			continue
+		}
+
+		parent := s
+		for _, name := range f.Path[:len(f.Path)-1] {
+			props := properties(parent)
+			ps, ok := props[name].(Schema)
+			if !ok {
+				ps = Schema{"type": "object"}
+				props[name] = ps
+			}
+			parent = ps
+		}
+
+		name := f.Path[len(f.Path)-1]
+		props := properties(parent)
+		if ps, ok := props[name].(Schema); ok {
+			merge(ps, g.schema(f.Type, f.Rules))
+		} else {
+			props[name] = g.schema(f.Type, f.Rules)
+		}
+
+		if required(f.Rules) {
+			require(parent, name)
+		}
+	}
+
+	for _, c := range d.Rules {
+		g.apply(s, d.Type, c)
+	}
+}
+
+func (g generator) child(s Schema, keyword string, t reflect.Type, ds []validation.Descriptor) {
+	if cs, ok := s[keyword].(Schema); ok {
+		for _, d := range ds {
+			g.apply(cs, indirect(t), d)
+		}
+		return
+	}
+	s[keyword] = g.schema(t, ds)
+}
+
+// required reports whether the rules provided require a value.
+func required(ds []validation.Descriptor) bool {
+	for _, d := range ds {
+		switch d.Name {
+		case rule.NameRequired, rule.NameNotNil, rule.NameStrRequired:
+			return true
+		case validation.NameRules:
+			if required(d.Rules) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func require(s Schema, name string) {
+	names, _ := s["required"].([]string)
+	for _, n := range names {
+		if n == name {
+			return
+		}
+	}
+	s["required"] = append(names, name)
+}
+
+func properties(s Schema) Schema {
+	props, ok := s["properties"].(Schema)
+	if !ok {
+		props = Schema{}
+		s["properties"] = props
+	}
+	return props
+}
+
+// merge merges the schema src into dst.
+func merge(dst, src Schema) {
+	for k, v := range src {
+		switch k {
+		case "properties":
+			props := properties(dst)
+			for name, ps := range v.(Schema) {
+				if dps, ok := props[name].(Schema); ok {
+					if sps, ok := ps.(Schema); ok {
+						merge(dps, sps)
+						continue
+					}
+				}
+				props[name] = ps
+			}
+		case "required":
+			for _, name := range v.([]string) {
+				require(dst, name)
+			}
+		default:
+			dst[k] = v
+		}
+	}
+}
+
+// bound sets a numeric bound. Bounds of other types, e.g. time.Time, are not
+// representable in JSON Schema.
+func bound(s Schema, keyword string, v interface{}) {
+	switch v.(type) {
+	case int, uint, float32, float64:
+		s[keyword] = v
+	}
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func elem(t reflect.Type) reflect.Type {
+	t = indirect(t)
+	if t == nil {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return t.Elem()
+	default:
+		return nil
+	}
+}
+
+func key(t reflect.Type) reflect.Type {
+	t = indirect(t)
+	if t == nil || t.Kind() != reflect.Map {
+		return nil
+	}
+	return t.Key()
+}
+
+// typeSchema creates a schema of the Go type t.
+func typeSchema(t reflect.Type) Schema {
+	t = indirect(t)
+	if t == nil {
+		return Schema{}
+	}
+
+	if t == timeType {
+		return Schema{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array"}
+	case reflect.Map, reflect.Struct:
+		return Schema{"type": "object"}
+	default:
+		return Schema{}
+	}
+}
@@ -0,0 +1,227 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/jsonschema"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+type Address struct {
+	Country string `json:"country"`
+	ZipCode string `json:"zipCode"`
+}
+
+type User struct {
+	Email   string            `json:"email"`
+	Name    string            `json:"name"`
+	Age     int               `json:"age"`
+	Role    string            `json:"role"`
+	Tags    []string          `json:"tags"`
+	Address Address           `json:"address"`
+	Labels  map[string]string `json:"labels"`
+	Nick    *string           `json:"nick"`
+}
+
+func tagIter(v interface{}, i int) interface{} {
+	return &(*(v.(*[]string)))[i]
+}
+
+var addressRule = validation.Struct(&Address{}, "json", []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Address).Country
+		},
+		Rules: []validation.Rule{rule.StrRequired("required")},
+	},
+})
+
+var evenRule = validation.Described(validation.Func(func(interface{}) error {
+	return nil
+}), func() validation.Descriptor {
+	return validation.Descriptor{Name: "custom.even"}
+})
+
+var userRule = validation.Struct(&User{}, "json", []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Email
+		},
+		Rules: []validation.Rule{
+			rule.StrRequired("required"),
+			rule.StrEmail("invalid email"),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Name
+		},
+		Rules: []validation.Rule{
+			rule.StrLen(2, 32, "invalid length"),
+			rule.StrMatch(regexp.MustCompile(`^[a-z]+$`), "invalid name"),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Age
+		},
+		Rules: []validation.Rule{rule.Between(18, 99, "invalid age"), evenRule},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Role
+		},
+		Rules: []validation.Rule{
+			rule.In([]interface{}{"admin", "user"}, "invalid role"),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Tags
+		},
+		Rules: []validation.Rule{
+			rule.SliceMaxLen(3, "too many tags"),
+			rule.SliceUnique(tagIter, "duplicated tag"),
+			rule.SliceEach(tagIter, []validation.Rule{rule.StrMinLen(1, "blank tag")}),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Address
+		},
+		Rules: []validation.Rule{addressRule},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Address.ZipCode
+		},
+		Rules: []validation.Rule{rule.StrMaxLen(6, "invalid zip code")},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Labels
+		},
+		Rules: []validation.Rule{
+			rule.MapMaxLen(2, "too many labels"),
+			rule.MapValues([]validation.Rule{rule.StrMaxLen(8, "too long")}),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Nick
+		},
+		Rules: []validation.Rule{
+			rule.Optional(rule.StrMinLen(3, "too short")),
+		},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return v
+		},
+		Rules: []validation.Rule{
+			validation.Func(func(interface{}) error {
+				return errors.New("opaque")
+			}),
+		},
+	},
+})
+
+const userSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["email"],
+	"properties": {
+		"email": {"type": "string", "minLength": 1, "format": "email"},
+		"name": {"type": "string", "minLength": 2, "maxLength": 32, "pattern": "^[a-z]+$"},
+		"age": {"type": "integer", "minimum": 18, "maximum": 99, "multipleOf": 2},
+		"role": {"type": "string", "enum": ["admin", "user"]},
+		"tags": {
+			"type": "array",
+			"maxItems": 3,
+			"uniqueItems": true,
+			"items": {"type": "string", "minLength": 1}
+		},
+		"address": {
+			"type": "object",
+			"required": ["country"],
+			"properties": {
+				"country": {"type": "string", "minLength": 1},
+				"zipCode": {"type": "string", "maxLength": 6}
+			}
+		},
+		"labels": {
+			"type": "object",
+			"maxProperties": 2,
+			"additionalProperties": {"type": "string", "maxLength": 8}
+		},
+		"nick": {"type": "string", "minLength": 3}
+	}
+}`
+
+func TestNew(t *testing.T) {
+	var unknown []string
+	s := jsonschema.New(userRule, func(d validation.Descriptor, s jsonschema.Schema) {
+		unknown = append(unknown, d.Name)
+		if d.Name == "custom.even" {
+			s["multipleOf"] = 2
+		}
+	})
+
+	b, err := json.Marshal(s)
+	require.Nil(t, err)
+	require.JSONEq(t, userSchema, string(b))
+	require.Equal(t, []string{"custom.even", ""}, unknown)
+}
+
+func TestNewNilExtension(t *testing.T) {
+	s := jsonschema.New(rule.StrMinLen(1, "blank"), nil)
+	require.Equal(t, jsonschema.Schema{
+		"$schema":   jsonschema.Draft,
+		"minLength": 1,
+	}, s)
+}
+
+func TestNewWrappedRule(t *testing.T) {
+	short := func(ctx interface{}) func(interface{}) error {
+		long := rule.StrMinLen(3, "too long")(ctx)
+		return func(v interface{}) error {
+			if long(v) == nil {
+				return errors.New("too long")
+			}
+			return nil
+		}
+	}
+	s := jsonschema.New(short, nil)
+	require.Equal(t, jsonschema.Schema{"$schema": jsonschema.Draft}, s)
+}
+
+func TestNewIgnoredField(t *testing.T) {
+	type Login struct {
+		Name     string `json:"name"`
+		Password string `json:"-"`
+	}
+	r := validation.Struct(&Login{}, "json", []validation.Field{
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*Login).Name
+			},
+			Rules: []validation.Rule{rule.StrRequired("required")},
+		},
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*Login).Password
+			},
+			Rules: []validation.Rule{rule.StrRequired("required")},
+		},
+	})
+	s := jsonschema.New(r, nil)
+	require.Equal(t, jsonschema.Schema{
+		"name": jsonschema.Schema{"type": "string", "minLength": 1},
+	}, s["properties"])
+}
@@ -0,0 +1,165 @@
+// Package lookup provides validation rules checking values against an
+// external storage. The values are collected during the validation and
+// resolved by a single batched call per set after it.
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+// Names of the rules described by the package. The names are the codes of
+// the rule errors as well.
+const (
+	NameUnique = "lookup.unique"
+	NameExists = "lookup.exists"
+)
+
+// ParamSet is the name of the set parameter.
+var ParamSet = "set"
+
+var (
+	errorBatch = validation.Panic{Err: errors.New("lookup batch not found")}
+)
+
+// Store represents a storage of value sets.
+type Store interface {
+	// Exists returns the values from the batch provided which exist in the
+	// set.
+	Exists(ctx context.Context, set string, values []interface{}) (map[interface{}]bool, error)
+}
+
+// batch collects the values looked up during the validation.
+type batch struct {
+	mu     sync.Mutex
+	values map[string][]interface{}
+	seen   map[string]map[interface{}]bool
+}
+
+func (b *batch) add(set string, v interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.seen[set] == nil {
+		b.seen[set] = map[interface{}]bool{}
+	}
+	if !b.seen[set][v] {
+		b.seen[set][v] = true
+		b.values[set] = append(b.values[set], v)
+	}
+}
+
+// pending is a lookup not resolved yet. It is placed in the error tree at the
+// path of the value looked up and replaced by the lookup result after the
+// validation. It is Deferred, so it does not stop the validation in bail
+// modes.
+type pending struct {
+	name   string
+	msg    string
+	set    string
+	value  interface{}
+	exists bool
+}
+
+func (e pending) Error() string {
+	return fmt.Sprintf("lookup of %v in %s is pending", e.value, e.set)
+}
+
+// Deferred marks the lookup as resolved after the validation.
+func (pending) Deferred() {}
+
+var batchKey = validation.NewKey[*batch]("lookup")
+
+func lookupRule(name, set, msg string, exists bool) validation.Rule {
+	return validation.Described(func(ctx interface{}) func(interface{}) error {
+		b, ok := validation.Value(ctx, batchKey)
+		dry := validation.DryRun(ctx)
+		return func(v interface{}) error {
+			if !ok && !dry {
+				return errorBatch
+			}
+
+			p := reflect.ValueOf(v)
+			if p.Kind() != reflect.Ptr || p.IsNil() {
+				return validation.Panic{
+					Err: fmt.Errorf("unexpected type: %v", reflect.TypeOf(v)),
+				}
+			}
+
+			x := p.Elem().Interface()
+			if !p.Elem().Type().Comparable() {
+				return validation.Panic{
+					Err: fmt.Errorf("uncomparable type: %v", p.Elem().Type()),
+				}
+			}
+
+			if dry {
+				return nil
+			}
+
+			b.add(set, x)
+			return pending{name: name, msg: msg, set: set, value: x, exists: exists}
+		}
+	}, func() validation.Descriptor {
+		return validation.Descriptor{
+			Name:    name,
+			Message: msg,
+			Params:  validation.Params{ParamSet: set},
+		}
+	})
+}
+
+// Unique creates validator to check whether a value does not exist in the
+// set. The rule must be validated with Validate.
+func Unique(set, msg string) validation.Rule {
+	return lookupRule(NameUnique, set, msg, false)
+}
+
+// Exists creates validator to check whether a value exists in the set. The
+// rule must be validated with Validate.
+func Exists(set, msg string) validation.Rule {
+	return lookupRule(NameExists, set, msg, true)
+}
+
+// Validate validates v against the rule and resolves the lookups by a
+// single Store call per set after the validation. The lookups stay in the
+// error tree at the paths of the values checked until they are resolved, the
+// lookup errors replace them. Lookups do not stop the validation in bail
+// modes. Store errors are returned as is.
+func Validate(ctx context.Context, store Store, rule validation.Rule, v interface{}) error {
+	b := &batch{
+		values: map[string][]interface{}{},
+		seen:   map[string]map[interface{}]bool{},
+	}
+	ctx = validation.WithValue(ctx, batchKey, b)
+
+	err := validation.Validate(ctx, rule, v)
+	if err == nil || validation.Fatal(err) || len(b.values) == 0 {
+		return err
+	}
+
+	found := map[string]map[interface{}]bool{}
+	for set, values := range b.values {
+		res, err := store.Exists(ctx, set, values)
+		if err != nil {
+			return err
+		}
+		found[set] = res
+	}
+
+	return validation.Map(err, func(_ validation.Path, leaf error) error {
+		p, ok := leaf.(pending)
+		if !ok {
+			return leaf
+		}
+		if found[p.set][p.value] != p.exists {
+			return validation.Error{Code: p.name, Message: p.msg}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,210 @@
+package lookup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/lookup"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+const (
+	eEmailInUse     = "email in use"
+	eUnknownCountry = "unknown country"
+)
+
+type User struct {
+	Email   string
+	Country string
+}
+
+var userRule = validation.Struct(&User{}, "", []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Email
+		},
+		Rules: []validation.Rule{lookup.Unique("emails", eEmailInUse)},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*User).Country
+		},
+		Rules: []validation.Rule{lookup.Exists("countries", eUnknownCountry)},
+	},
+})
+
+func userIter(v interface{}, i int) interface{} {
+	return &(*(v.(*[]User)))[i]
+}
+
+var usersRule = rule.SliceEach(userIter, []validation.Rule{userRule})
+
+type countingStore struct {
+	lookup.Store
+	calls map[string]int
+}
+
+func (s *countingStore) Exists(ctx context.Context, set string, values []interface{}) (map[interface{}]bool, error) {
+	s.calls[set]++
+	return s.Store.Exists(ctx, set, values)
+}
+
+func newStore() *countingStore {
+	return &countingStore{
+		Store: lookup.NewMemory(map[string][]interface{}{
+			"emails":    {"user1@mail.com", "user2@mail.com"},
+			"countries": {"NL", "US"},
+		}),
+		calls: map[string]int{},
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Exists(context.Context, string, []interface{}) (map[interface{}]bool, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("PanicIfNoBatch", func(t *testing.T) {
+		err := validation.Validate(context.Background(), userRule, &User{})
+		require.IsType(t, validation.Panic{}, err)
+	})
+	t.Run("ErrorIfStoreFails", func(t *testing.T) {
+		err := lookup.Validate(context.Background(), failingStore{}, userRule, &User{})
+		require.EqualError(t, err, "connection refused")
+	})
+	t.Run("OkIfResolved", func(t *testing.T) {
+		store := newStore()
+		v := []User{
+			{Email: "user3@mail.com", Country: "NL"},
+			{Email: "user4@mail.com", Country: "US"},
+		}
+		require.Nil(t, lookup.Validate(context.Background(), store, usersRule, &v))
+	})
+	t.Run("ErrorsAtValuePaths", func(t *testing.T) {
+		store := newStore()
+		v := []User{
+			{Email: "user1@mail.com", Country: "NL"},
+			{Email: "user3@mail.com", Country: "US"},
+			{Email: "user2@mail.com", Country: "XX"},
+			{Email: "user3@mail.com", Country: "NL"},
+		}
+		err := lookup.Validate(context.Background(), store, usersRule, &v)
+		require.Equal(t, validation.Errors{
+			validation.SliceError{
+				Index: 0,
+				Errors: validation.Errors{
+					validation.StructError{
+						Field:  "Email",
+						Errors: validation.Errors{validation.Error{Code: lookup.NameUnique, Message: eEmailInUse}},
+					},
+				},
+			},
+			validation.SliceError{
+				Index: 2,
+				Errors: validation.Errors{
+					validation.StructError{
+						Field:  "Email",
+						Errors: validation.Errors{validation.Error{Code: lookup.NameUnique, Message: eEmailInUse}},
+					},
+					validation.StructError{
+						Field:  "Country",
+						Errors: validation.Errors{validation.Error{Code: lookup.NameExists, Message: eUnknownCountry}},
+					},
+				},
+			},
+		}, err)
+		require.Equal(t, map[string]int{"emails": 1, "countries": 1}, store.calls)
+	})
+	t.Run("KeepsOtherErrors", func(t *testing.T) {
+		r := validation.Rules([]validation.Rule{
+			rule.StrRequired("required"),
+			lookup.Unique("emails", eEmailInUse),
+		})
+		v := ""
+		err := lookup.Validate(context.Background(), newStore(), r, &v)
+		require.Equal(t, validation.Errors{
+			validation.Error{Code: rule.NameStrRequired, Message: "required"},
+		}, err)
+	})
+}
+
+func TestValidateBail(t *testing.T) {
+	r := validation.Struct(&User{}, "", []validation.Field{
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*User).Email
+			},
+			Rules: []validation.Rule{lookup.Unique("emails", eEmailInUse)},
+		},
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*User).Country
+			},
+			Rules: []validation.Rule{rule.StrRequired("required")},
+		},
+	})
+
+	for _, mode := range []validation.Mode{validation.ModeAll, validation.ModeBailField, validation.ModeFailFast} {
+		ctx := validation.WithMode(context.Background(), mode)
+
+		err := lookup.Validate(ctx, newStore(), r, &User{Email: "user3@mail.com"})
+		require.Equal(t, validation.Errors{
+			validation.StructError{
+				Field:  "Country",
+				Errors: validation.Errors{validation.Error{Code: rule.NameStrRequired, Message: "required"}},
+			},
+		}, err, "mode %d", mode)
+
+		err = lookup.Validate(ctx, newStore(), r, &User{Email: "user1@mail.com", Country: "NL"})
+		require.Equal(t, validation.Errors{
+			validation.StructError{
+				Field:  "Email",
+				Errors: validation.Errors{validation.Error{Code: lookup.NameUnique, Message: eEmailInUse}},
+			},
+		}, err, "mode %d", mode)
+	}
+}
+
+func TestValidateOnce(t *testing.T) {
+	calls := 0
+	r := validation.Rules([]validation.Rule{
+		validation.Func(func(interface{}) error {
+			calls++
+			return nil
+		}),
+		lookup.Unique("emails", eEmailInUse),
+	})
+
+	v := "user1@mail.com"
+	err := lookup.Validate(context.Background(), newStore(), r, &v)
+	require.Equal(t, validation.Errors{
+		validation.Error{Code: lookup.NameUnique, Message: eEmailInUse},
+	}, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestDescribe(t *testing.T) {
+	require.Equal(t, validation.Descriptor{
+		Name:    lookup.NameUnique,
+		Message: eEmailInUse,
+		Params:  validation.Params{lookup.ParamSet: "emails"},
+	}, validation.Describe(lookup.Unique("emails", eEmailInUse)))
+}
+
+func TestVerify(t *testing.T) {
+	_, err := validation.Compile(&User{}, "", []validation.Field{
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*User).Email
+			},
+			Rules: []validation.Rule{lookup.Unique("emails", eEmailInUse)},
+		},
+	}, validation.Verify())
+	require.Nil(t, err)
+}
@@ -0,0 +1,48 @@
+package lookup
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory represents an in-memory Store.
+type Memory struct {
+	mu   sync.RWMutex
+	sets map[string]map[interface{}]bool
+}
+
+// NewMemory creates new in-memory Store holding the sets provided.
+func NewMemory(sets map[string][]interface{}) *Memory {
+	m := &Memory{sets: map[string]map[interface{}]bool{}}
+	for set, values := range sets {
+		m.Add(set, values...)
+	}
+	return m
+}
+
+// Add adds values to the set.
+func (m *Memory) Add(set string, values ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sets[set] == nil {
+		m.sets[set] = map[interface{}]bool{}
+	}
+	for _, v := range values {
+		m.sets[set][v] = true
+	}
+}
+
+// Exists returns the values from the batch provided which exist in the set.
+func (m *Memory) Exists(_ context.Context, set string, values []interface{}) (map[interface{}]bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := map[interface{}]bool{}
+	for _, v := range values {
+		if m.sets[set][v] {
+			res[v] = true
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,23 @@
+package lookup_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation/lookup"
+)
+
+func TestMemory(t *testing.T) {
+	m := lookup.NewMemory(map[string][]interface{}{"ids": {1, 2}})
+	m.Add("ids", 3)
+
+	res, err := m.Exists(context.Background(), "ids", []interface{}{1, 3, 4})
+	require.Nil(t, err)
+	require.Equal(t, map[interface{}]bool{1: true, 3: true}, res)
+
+	res, err = m.Exists(context.Background(), "names", []interface{}{1})
+	require.Nil(t, err)
+	require.Empty(t, res)
+}
@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"reflect"
+)
+
+// Merge merges error trees. Nodes of the same struct field, slice index or
+// map key are merged into a single node, the order of the nodes and leaves
+// is the order of their first occurrence. It returns nil if there are no
+// errors.
+func Merge(errs ...error) error {
+	return merge(errs, false)
+}
+
+// MergeDistinct merges error trees like Merge and drops the leaves
+// identical to a leaf already present at the same path.
+func MergeDistinct(errs ...error) error {
+	return merge(errs, true)
+}
+
+func merge(errs []error, distinct bool) error {
+	var res Errors
+	for _, err := range errs {
+		if err != nil {
+			res = mergeInto(res, err, distinct)
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+func mergeInto(dst Errors, err error, distinct bool) Errors {
+	if errs, ok := err.(Errors); ok {
+		for _, e := range errs {
+			dst = mergeInto(dst, e, distinct)
+		}
+		return dst
+	}
+
+	if s, children, ok := segment(err); ok {
+		for i, d := range dst {
+			if ds, merged, ok := segment(d); ok && ds.Kind == s.Kind && ds.equal(s) {
+				merged = append(Errors(nil), merged...)
+				for _, c := range children {
+					merged = mergeInto(merged, c, distinct)
+				}
+				dst[i] = node(ds, merged)
+				return dst
+			}
+		}
+
+		var merged Errors
+		for _, c := range children {
+			merged = mergeInto(merged, c, distinct)
+		}
+		if len(merged) == 0 {
+			return dst
+		}
+		return append(dst, node(s, merged))
+	}
+
+	if distinct {
+		for _, d := range dst {
+			if identical(d, err) {
+				return dst
+			}
+		}
+	}
+
+	return append(dst, err)
+}
+
+// identical reports whether the leaf errors are identical. Validation errors
+// are compared by value, other errors by type and message.
+func identical(a, b error) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	if _, ok := a.(Error); ok {
+		return reflect.DeepEqual(a, b)
+	}
+	return a.Error() == b.Error()
+}
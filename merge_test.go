@@ -0,0 +1,83 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+func fieldErr(name string, errs ...error) validation.StructError {
+	return validation.StructError{Field: name, Errors: errs}
+}
+
+func itemErr(i int, errs ...error) validation.SliceError {
+	return validation.SliceError{Index: i, Errors: errs}
+}
+
+var (
+	errBlank = validation.Error{Code: "str.required", Message: "blank"}
+	errEmail = validation.Error{Code: "str.email", Message: "invalid email"}
+	errInUse = validation.Error{Code: "lookup.unique", Message: "in use"}
+	errZip   = validation.Error{Code: "str.match", Message: "invalid zip"}
+)
+
+var (
+	structural = validation.Errors{
+		fieldErr("email", errBlank, errEmail),
+		fieldErr("addresses", itemErr(0, fieldErr("zipCode", errZip))),
+	}
+	business = validation.Errors{
+		fieldErr("addresses", itemErr(1, fieldErr("zipCode", errZip))),
+		fieldErr("email", errInUse, errEmail),
+		fieldErr("addresses", itemErr(0, fieldErr("country", errBlank))),
+	}
+)
+
+func TestMerge(t *testing.T) {
+	t.Run("NilIfNoErrors", func(t *testing.T) {
+		require.Nil(t, validation.Merge())
+		require.Nil(t, validation.Merge(nil, validation.Errors{}))
+	})
+	t.Run("MergesNodes", func(t *testing.T) {
+		require.Equal(t, validation.Errors{
+			fieldErr("email", errBlank, errEmail, errInUse, errEmail),
+			fieldErr("addresses",
+				itemErr(0, fieldErr("zipCode", errZip), fieldErr("country", errBlank)),
+				itemErr(1, fieldErr("zipCode", errZip)),
+			),
+		}, validation.Merge(structural, business))
+	})
+	t.Run("Distinct", func(t *testing.T) {
+		require.Equal(t, validation.Errors{
+			fieldErr("email", errBlank, errEmail, errInUse),
+			fieldErr("addresses",
+				itemErr(0, fieldErr("zipCode", errZip), fieldErr("country", errBlank)),
+				itemErr(1, fieldErr("zipCode", errZip)),
+			),
+		}, validation.MergeDistinct(structural, business))
+	})
+	t.Run("DistinctPlainErrors", func(t *testing.T) {
+		require.Equal(t, validation.Errors{
+			fieldErr("name", errors.New("bad")),
+		}, validation.MergeDistinct(
+			fieldErr("name", errors.New("bad")),
+			fieldErr("name", errors.New("bad")),
+		))
+	})
+	t.Run("KeepsInputs", func(t *testing.T) {
+		a := validation.Errors{fieldErr("email", errBlank)}
+		validation.Merge(a, validation.Errors{fieldErr("email", errEmail)})
+		require.Equal(t, validation.Errors{fieldErr("email", errBlank)}, a)
+	})
+	t.Run("MapKeys", func(t *testing.T) {
+		require.Equal(t, validation.Errors{
+			validation.MapError{Key: "env", Errors: validation.Errors{errBlank, errZip}},
+		}, validation.Merge(
+			validation.MapError{Key: "env", Errors: validation.Errors{errBlank}},
+			validation.MapError{Key: "env", Errors: validation.Errors{errZip}},
+		))
+	})
+}
@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"context"
+)
+
+// Mode defines when a validation stops collecting errors.
+type Mode int
+
+const (
+	// ModeInherit uses the mode of the enclosing scope.
+	ModeInherit Mode = iota
+	// ModeAll runs all the rules and collects all the errors.
+	ModeAll
+	// ModeBailRule stops validating a value at its first failing rule.
+	ModeBailRule
+	// ModeBailField stops validating a struct at its first failing field and
+	// a slice at its first failing item.
+	ModeBailField
+	// ModeFailFast stops the whole validation at the first error.
+	ModeFailFast
+)
+
+type modeKey struct{}
+
+// WithMode returns a copy of ctx holding the validation mode provided. The
+// mode applies to all the rules bound to the context.
+func WithMode(ctx context.Context, mode Mode) context.Context {
+	return context.WithValue(ctx, modeKey{}, mode)
+}
+
+// withMode returns a context holding the mode provided unless it is
+// ModeInherit. Contexts other than context.Context can not hold a mode, they
+// are returned as is.
+func withMode(ctx interface{}, mode Mode) interface{} {
+	if mode == ModeInherit {
+		return ctx
+	}
+	switch c := ctx.(type) {
+	case nil:
+		return WithMode(context.Background(), mode)
+	case context.Context:
+		return WithMode(c, mode)
+	default:
+		return ctx
+	}
+}
+
+// ModeOf gets the validation mode from a validation context. It returns
+// ModeAll if the context does not hold a mode.
+func ModeOf(ctx interface{}) Mode {
+	if c, ok := ctx.(context.Context); ok {
+		if m, ok := c.Value(modeKey{}).(Mode); ok && m != ModeInherit {
+			return m
+		}
+	}
+	return ModeAll
+}
+
+// Or returns m unless it is ModeInherit, otherwise it returns mode.
+func (m Mode) Or(mode Mode) Mode {
+	if m == ModeInherit {
+		return mode
+	}
+	return m
+}
+
+// BailRule reports whether the mode stops at the first failing rule.
+func (m Mode) BailRule() bool {
+	return m == ModeBailRule || m == ModeFailFast
+}
+
+// BailField reports whether the mode stops at the first failing field or
+// slice item.
+func (m Mode) BailField() bool {
+	return m == ModeBailField || m == ModeFailFast
+}
@@ -0,0 +1,169 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+func addressRuleMode(mode validation.Mode) validation.Rule {
+	return validation.Struct(&Address{}, ``, []validation.Field{
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*Address).Country
+			},
+			Rules: []validation.Rule{
+				validation.Func(stringRequired),
+				validation.Func(startsUpperCase),
+			},
+			Mode: mode,
+		},
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*Address).ZipCode
+			},
+			Rules: []validation.Rule{
+				validation.Func(stringRequired),
+				validation.Func(zipCode),
+			},
+		},
+	})
+}
+
+func TestMode(t *testing.T) {
+	t.Run("AllByDefault", func(t *testing.T) {
+		err := validation.Validate(context.Background(), addressRuleMode(validation.ModeInherit), &Address{})
+		require.Equal(t, addressFixtures[Address{}], err)
+	})
+	t.Run("BailRulePerField", func(t *testing.T) {
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field:  "Country",
+				Errors: []error{errors.New(eRequired)},
+			},
+			validation.StructError{
+				Field:  "ZipCode",
+				Errors: []error{errors.New(eRequired)},
+			},
+		})
+		err := validation.Validate(context.Background(), addressRuleMode(validation.ModeBailRule), &Address{})
+		require.Equal(t, exp, err)
+	})
+	t.Run("FieldOverridesGlobal", func(t *testing.T) {
+		ctx := validation.WithMode(context.Background(), validation.ModeBailRule)
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field: "Country",
+				Errors: []error{
+					errors.New(eRequired),
+					errors.New(eStartsUpperCase),
+				},
+			},
+			validation.StructError{
+				Field:  "ZipCode",
+				Errors: []error{errors.New(eRequired)},
+			},
+		})
+		err := validation.Validate(ctx, addressRuleMode(validation.ModeAll), &Address{})
+		require.Equal(t, exp, err)
+	})
+	t.Run("BailFieldGlobal", func(t *testing.T) {
+		ctx := validation.WithMode(context.Background(), validation.ModeBailField)
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field: "Country",
+				Errors: []error{
+					errors.New(eRequired),
+					errors.New(eStartsUpperCase),
+				},
+			},
+		})
+		err := validation.Validate(ctx, addressRuleMode(validation.ModeInherit), &Address{})
+		require.Equal(t, exp, err)
+	})
+	t.Run("FailFastGlobal", func(t *testing.T) {
+		ctx := validation.WithMode(context.Background(), validation.ModeFailFast)
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field:  "Country",
+				Errors: []error{errors.New(eRequired)},
+			},
+		})
+		err := validation.Validate(ctx, addressRuleMode(validation.ModeInherit), &Address{})
+		require.Equal(t, exp, err)
+	})
+	t.Run("BailFieldPerField", func(t *testing.T) {
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field: "Country",
+				Errors: []error{
+					errors.New(eRequired),
+					errors.New(eStartsUpperCase),
+				},
+			},
+		})
+		err := validation.Validate(context.Background(), addressRuleMode(validation.ModeBailField), &Address{})
+		require.Equal(t, exp, err)
+		require.Equal(t, exp, addressRuleMode(validation.ModeBailField)([]string{"a"})(&Address{}))
+	})
+	t.Run("FailFastPerField", func(t *testing.T) {
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field:  "Country",
+				Errors: []error{errors.New(eRequired)},
+			},
+		})
+		err := validation.Validate(context.Background(), addressRuleMode(validation.ModeFailFast), &Address{})
+		require.Equal(t, exp, err)
+	})
+	t.Run("FieldModeNested", func(t *testing.T) {
+		r := validation.Struct(&Customer{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Customer).Addresses
+				},
+				Rules: []validation.Rule{
+					rule.SliceEach(addressIter, []validation.Rule{addressRule}),
+				},
+				Mode: validation.ModeBailField,
+			},
+		})
+		v := &Customer{Addresses: []Address{{}, {}}}
+
+		err := validation.Validate(context.Background(), r, v)
+		require.Equal(t, validation.Errors{
+			validation.StructError{Field: "addresses", Errors: []error{
+				validation.SliceError{Index: 0, Errors: addressFixtures[Address{}].(validation.Errors)[:1]},
+			}},
+		}, err)
+	})
+}
+
+func TestRulesMode(t *testing.T) {
+	rules := []validation.Rule{
+		validation.Func(stringRequired),
+		validation.Func(email),
+	}
+	v := ""
+
+	t.Run("BailRule", func(t *testing.T) {
+		exp := validation.Errors([]error{errors.New(eRequired)})
+		require.Equal(t, exp, validation.RulesMode(validation.ModeBailRule, rules)(nil)(&v))
+	})
+	t.Run("InheritGlobal", func(t *testing.T) {
+		ctx := validation.WithMode(context.Background(), validation.ModeFailFast)
+		exp := validation.Errors([]error{errors.New(eRequired)})
+		require.Equal(t, exp, validation.Validate(ctx, validation.Rules(rules), &v))
+	})
+	t.Run("AllOverridesGlobal", func(t *testing.T) {
+		ctx := validation.WithMode(context.Background(), validation.ModeFailFast)
+		exp := validation.Errors([]error{errors.New(eRequired), errors.New(eEmail)})
+		rule := validation.RulesMode(validation.ModeAll, rules)
+		require.Equal(t, exp, validation.Validate(ctx, rule, &v))
+	})
+}
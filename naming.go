@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NameFunc resolves the name of a struct field reported in validation
+// errors. It returns false if the field is ignored. Errors of ignored fields
+// are still reported at the name returned, or at the Go name if it is empty,
+// but ignored fields are left out of the schema exports.
+type NameFunc func(f reflect.StructField) (string, bool)
+
+// GoName names the struct fields by their Go names.
+func GoName(f reflect.StructField) (string, bool) {
+	return f.Name, true
+}
+
+// SnakeCase names the struct fields by their Go names in snake case, e.g.
+// ZipCode becomes zip_code and UserID becomes user_id.
+func SnakeCase(f reflect.StructField) (string, bool) {
+	words := splitWords(f.Name)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, "_"), true
+}
+
+// CamelCase names the struct fields by their Go names in lower camel case,
+// e.g. ZipCode becomes zipCode and ID becomes id.
+func CamelCase(f reflect.StructField) (string, bool) {
+	words := splitWords(f.Name)
+	if len(words) > 0 {
+		words[0] = strings.ToLower(words[0])
+	}
+	return strings.Join(words, ""), true
+}
+
+// splitWords splits a Go name into words, acronyms are kept as single words,
+// e.g. HTTPServer is split into HTTP and Server.
+func splitWords(s string) []string {
+	rs := []rune(s)
+
+	var words []string
+	start := 0
+	for i := 1; i < len(rs); i++ {
+		prev, cur := rs[i-1], rs[i]
+		next := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+		if unicode.IsUpper(cur) && (!unicode.IsUpper(prev) || next) {
+			words = append(words, string(rs[start:i]))
+			start = i
+		}
+	}
+	if start < len(rs) {
+		words = append(words, string(rs[start:]))
+	}
+
+	return words
+}
+
+// TagName creates a NameFunc taking the field names from the first of the
+// struct tags having one, e.g. TagName(GoName, "json", "form"). Tag options
+// like omitempty are stripped. As in encoding/json, the fields tagged "-"
+// are ignored, while the fields tagged "-," are named "-". The fields without
+// names in the tags, including the ignored ones, are named by the fallback.
+func TagName(fallback NameFunc, tags ...string) NameFunc {
+	return func(f reflect.StructField) (string, bool) {
+		for _, tag := range tags {
+			v, ok := f.Tag.Lookup(tag)
+			if !ok {
+				continue
+			}
+			if v == "-" {
+				name, _ := fallback(f)
+				return name, false
+			}
+			if name, _, _ := strings.Cut(v, ","); name != "" {
+				return name, true
+			}
+		}
+		return fallback(f)
+	}
+}
+
+// Option configures a struct schema.
+type Option func(*Schema)
+
+// Naming sets the function naming the struct fields, it overrides the tag
+// passed to Compile or Struct.
+func Naming(fn NameFunc) Option {
+	return func(s *Schema) {
+		s.name = fn
+	}
+}
+
+// naming returns the NameFunc of the tag provided.
+func naming(tag string) NameFunc {
+	if tag == "" {
+		return GoName
+	}
+	return TagName(GoName, tag)
+}
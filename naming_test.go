@@ -0,0 +1,137 @@
+package validation_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+type Signup struct {
+	UserID   string `json:"user_id,omitempty"`
+	Email    string `form:"email_address"`
+	Password string `json:"-"`
+	HTTPHost string `json:",omitempty" form:"host"`
+	Location Address
+	Secret   Address `json:"-"`
+}
+
+func signupField(attr validation.Attr) validation.Field {
+	return validation.Field{
+		Attr:  attr,
+		Rules: []validation.Rule{validation.Func(stringRequired)},
+	}
+}
+
+var signupFields = []validation.Field{
+	signupField(func(v interface{}) interface{} {
+		return &v.(*Signup).UserID
+	}),
+	signupField(func(v interface{}) interface{} {
+		return &v.(*Signup).Email
+	}),
+	signupField(func(v interface{}) interface{} {
+		return &v.(*Signup).Password
+	}),
+	signupField(func(v interface{}) interface{} {
+		return &v.(*Signup).HTTPHost
+	}),
+	signupField(func(v interface{}) interface{} {
+		return &v.(*Signup).Location.ZipCode
+	}),
+	signupField(func(v interface{}) interface{} {
+		return &v.(*Signup).Secret.ZipCode
+	}),
+}
+
+func signupErrors(names ...string) validation.Errors {
+	blank := []error{errors.New(eRequired)}
+	return validation.Errors{
+		validation.StructError{Field: names[0], Errors: blank},
+		validation.StructError{Field: names[1], Errors: blank},
+		validation.StructError{Field: names[2], Errors: blank},
+		validation.StructError{Field: names[3], Errors: blank},
+		validation.StructError{
+			Field:  names[4],
+			Errors: []error{validation.StructError{Field: names[5], Errors: blank}},
+		},
+		validation.StructError{
+			Field:  names[6],
+			Errors: []error{validation.StructError{Field: names[5], Errors: blank}},
+		},
+	}
+}
+
+func TestNaming(t *testing.T) {
+	t.Run("TagOptionsStripped", func(t *testing.T) {
+		err := validation.Struct(&Signup{}, "json", signupFields)(nil)(&Signup{})
+		require.Equal(t, signupErrors(
+			"user_id", "Email", "Password", "HTTPHost", "Location", "zipCode", "Secret"), err)
+	})
+	t.Run("TagFallbacks", func(t *testing.T) {
+		naming := validation.TagName(validation.CamelCase, "json", "form")
+		err := validation.Struct(&Signup{}, "", signupFields, validation.Naming(naming))(nil)(&Signup{})
+		require.Equal(t, signupErrors(
+			"user_id", "email_address", "password", "host", "location", "zipCode", "secret"), err)
+	})
+	t.Run("SnakeCase", func(t *testing.T) {
+		err := validation.Struct(&Signup{}, "json", signupFields, validation.Naming(validation.SnakeCase))(nil)(&Signup{})
+		require.Equal(t, validation.Errors{
+			validation.StructError{Field: "user_id", Errors: []error{errors.New(eRequired)}},
+			validation.StructError{Field: "email", Errors: []error{errors.New(eRequired)}},
+			validation.StructError{Field: "password", Errors: []error{errors.New(eRequired)}},
+			validation.StructError{Field: "http_host", Errors: []error{errors.New(eRequired)}},
+			validation.StructError{Field: "location", Errors: []error{
+				validation.StructError{Field: "zip_code", Errors: []error{errors.New(eRequired)}},
+			}},
+			validation.StructError{Field: "secret", Errors: []error{
+				validation.StructError{Field: "zip_code", Errors: []error{errors.New(eRequired)}},
+			}},
+		}, err)
+	})
+	t.Run("VerifyAcceptsIgnoredFields", func(t *testing.T) {
+		_, err := validation.Compile(&Signup{}, "json", signupFields, validation.Verify())
+		require.Nil(t, err)
+	})
+}
+
+func TestTagName(t *testing.T) {
+	naming := validation.TagName(validation.GoName, "json", "form")
+	for tag, exp := range map[string]struct {
+		name string
+		ok   bool
+	}{
+		`json:"-"`:                   {"ZipCode", false},
+		`json:"-,"`:                  {"-", true},
+		`json:"-,omitempty"`:         {"-", true},
+		`json:"zip,omitempty"`:       {"zip", true},
+		`json:",omitempty"`:          {"ZipCode", true},
+		`json:",omitempty" form:"z"`: {"z", true},
+		`form:"-"`:                   {"ZipCode", false},
+		``:                           {"ZipCode", true},
+	} {
+		name, ok := naming(reflect.StructField{Name: "ZipCode", Tag: reflect.StructTag(tag)})
+		require.Equal(t, exp.name, name, tag)
+		require.Equal(t, exp.ok, ok, tag)
+	}
+}
+
+func TestNameCase(t *testing.T) {
+	for name, exp := range map[string][2]string{
+		"ZipCode":    {"zip_code", "zipCode"},
+		"ID":         {"id", "id"},
+		"UserID":     {"user_id", "userID"},
+		"HTTPServer": {"http_server", "httpServer"},
+		"Address2":   {"address2", "address2"},
+		"A":          {"a", "a"},
+	} {
+		f := reflect.StructField{Name: name}
+		snake, _ := validation.SnakeCase(f)
+		camel, _ := validation.CamelCase(f)
+		require.Equal(t, exp[0], snake, name)
+		require.Equal(t, exp[1], camel, name)
+	}
+}
@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Recovered converts a value recovered from a Go panic to Panic.
+func Recovered(r interface{}) Panic {
+	switch e := r.(type) {
+	case Panic:
+		return e
+	case error:
+		return Panic{Err: e}
+	default:
+		return Panic{Err: fmt.Errorf("panic: %v", r)}
+	}
+}
+
+// Location locates a Panic: the path of the value validated, the descriptor
+// of the rule and the type of the value.
+type Location struct {
+	Path Path
+	Rule *Descriptor
+	Type reflect.Type
+}
+
+// String returns string representation of the location.
+func (l *Location) String() string {
+	var loc []string
+	if len(l.Path) > 0 {
+		loc = append(loc, "at "+l.Path.String())
+	}
+	if l.Rule != nil && l.Rule.Name != "" {
+		loc = append(loc, "rule "+l.Rule.Name)
+	}
+	if l.Type != nil {
+		loc = append(loc, "type "+l.Type.String())
+	}
+	return strings.Join(loc, ", ")
+}
+
+// Locate adds the location to err if it is Panic, other errors are returned
+// as is. The path is prepended to the path of the panic, the descriptor of
+// the rule and the type of v are recorded unless the panic has them already.
+// A nil rule or v is not recorded.
+func Locate(err error, rule Rule, v interface{}, path ...Segment) error {
+	e, ok := err.(Panic)
+	if !ok {
+		return err
+	}
+
+	var loc Location
+	if e.Loc != nil {
+		loc = *e.Loc
+	}
+
+	if len(path) > 0 {
+		p := make(Path, 0, len(path)+len(loc.Path))
+		loc.Path = append(append(p, path...), loc.Path...)
+	}
+	if loc.Rule == nil && rule != nil {
+		d := Describe(rule)
+		loc.Rule = &d
+	}
+	if loc.Type == nil && v != nil {
+		loc.Type = reflect.TypeOf(v)
+	}
+
+	if len(loc.Path) > 0 || loc.Rule != nil || loc.Type != nil {
+		e.Loc = &loc
+	}
+	return e
+}
+
+// fieldPath converts a struct field path to the Path segments.
+func fieldPath(names []string) Path {
+	p := make(Path, len(names))
+	for i, name := range names {
+		p[i] = AtField(name)
+	}
+	return p
+}
+
+type mustKey struct{}
+
+// WithMust returns a copy of ctx enabling the Must mode: Validate panics with
+// Panic instead of returning it. It is intended for tests, where a broken
+// schema should fail loudly.
+func WithMust(ctx context.Context) context.Context {
+	return context.WithValue(ctx, mustKey{}, true)
+}
+
+func must(ctx context.Context) bool {
+	m, _ := ctx.Value(mustKey{}).(bool)
+	return m
+}
@@ -0,0 +1,130 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+type Customer struct {
+	Name      string    `json:"name"`
+	Addresses []Address `json:"addresses"`
+	Manager   *User     `json:"manager"`
+}
+
+func addressIter(v interface{}, i int) interface{} {
+	return &(*(v.(*[]Address)))[i]
+}
+
+func customerRule(rules ...validation.Rule) validation.Rule {
+	return validation.Struct(&Customer{}, "json", []validation.Field{
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*Customer).Addresses
+			},
+			Rules: []validation.Rule{
+				rule.SliceEach(addressIter, []validation.Rule{
+					validation.Struct(&Address{}, "json", []validation.Field{
+						{
+							Attr: func(v interface{}) interface{} {
+								return &v.(*Address).ZipCode
+							},
+							Rules: rules,
+						},
+					}),
+				}),
+			},
+		},
+	})
+}
+
+var customer = Customer{Addresses: []Address{{}, {}, {ZipCode: "x"}}}
+
+func panicOnX(v interface{}) error {
+	if *v.(*string) == "x" {
+		panic("unexpected x")
+	}
+	return nil
+}
+
+func TestPanicLocation(t *testing.T) {
+	t.Run("LocatesRuleError", func(t *testing.T) {
+		v := customer
+		err := customerRule(rule.MapLen(1, 2, "len"))(nil)(&v)
+
+		p, ok := err.(validation.Panic)
+		require.True(t, ok)
+		require.Equal(t, "addresses[0].zipCode", p.Loc.Path.String())
+		require.Equal(t, rule.NameMapLen, p.Loc.Rule.Name)
+		require.Equal(t, reflect.TypeOf(new(string)), p.Loc.Type)
+		require.Equal(t,
+			"unexpected type: *string (at addresses[0].zipCode, rule map.len, type *string)",
+			p.Error())
+	})
+	t.Run("RecoversRulePanic", func(t *testing.T) {
+		v := customer
+		err := validation.Validate(context.Background(), customerRule(validation.Func(panicOnX)), &v)
+
+		p, ok := err.(validation.Panic)
+		require.True(t, ok)
+		require.EqualError(t, p.Err, "panic: unexpected x")
+		require.Equal(t, "addresses[2].zipCode", p.Loc.Path.String())
+		require.Equal(t, validation.NameCustom, p.Loc.Rule.Name)
+	})
+	t.Run("RecoversAttrPanic", func(t *testing.T) {
+		r := validation.Struct(&Customer{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Customer).Manager.Email
+				},
+				Rules: []validation.Rule{validation.Func(stringRequired)},
+			},
+		})
+
+		err := r(nil)(&Customer{})
+
+		p, ok := err.(validation.Panic)
+		require.True(t, ok)
+		var re interface{ RuntimeError() }
+		require.True(t, errors.As(err, &re))
+		require.Nil(t, p.Loc)
+	})
+	t.Run("RecoversParallelPanic", func(t *testing.T) {
+		v := customer
+		ctx := validation.WithWorkers(context.Background(), 4)
+		err := validation.Validate(ctx, customerRule(validation.Func(panicOnX)), &v)
+
+		p, ok := err.(validation.Panic)
+		require.True(t, ok)
+		require.Equal(t, "addresses[2].zipCode", p.Loc.Path.String())
+	})
+	t.Run("AttrNotPtr", func(t *testing.T) {
+		err := addressRuleGetAttrNotPtr(nil)(&Address{})
+		require.Equal(t, reflect.TypeOf(""), err.(validation.Panic).Loc.Type)
+	})
+}
+
+func TestMust(t *testing.T) {
+	ctx := validation.WithMust(context.Background())
+
+	t.Run("PanicsOnPanic", func(t *testing.T) {
+		v := customer
+		require.PanicsWithError(t,
+			"panic: unexpected x (at addresses[2].zipCode, type *string)",
+			func() {
+				validation.Validate(ctx, customerRule(validation.Func(panicOnX)), &v)
+			})
+	})
+	t.Run("ReturnsValidationErrors", func(t *testing.T) {
+		v := customer
+		err := validation.Validate(ctx, customerRule(rule.StrRequired("blank")), &v)
+		path, _ := validation.ParsePath("addresses[0].zipCode")
+		require.True(t, validation.HasErrors(err, path))
+	})
+}
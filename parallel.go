@@ -0,0 +1,137 @@
+package validation
+
+import (
+	"context"
+	"sync"
+)
+
+type pool struct {
+	sem chan struct{}
+}
+
+type poolKey struct{}
+
+// WithWorkers returns a copy of ctx enabling parallel validation of struct
+// fields, slice items and map entries. The validation uses up to n goroutines
+// besides the calling one, the limit is shared by all the rules bound to the
+// context. Errors are reported in the same order as in sequential mode.
+func WithWorkers(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, poolKey{}, &pool{sem: make(chan struct{}, n)})
+}
+
+func poolOf(ctx interface{}) *pool {
+	if c, ok := ctx.(context.Context); ok {
+		if p, ok := c.Value(poolKey{}).(*pool); ok && cap(p.sem) > 0 {
+			return p
+		}
+	}
+	return nil
+}
+
+// Each calls fn for every index in [0, n) and returns the errors in the index
+// order. The calls run concurrently if ctx enables parallel validation. If
+// bail is true the results are truncated after the first failure, see
+// Failed. A fatal error
+// cancels the calls not started yet and is returned as the second result.
+func Each(ctx interface{}, n int, bail bool, fn func(int) error) ([]error, error) {
+	res := make([]error, n)
+
+	p := poolOf(ctx)
+	if p == nil {
+		for i := 0; i < n; i++ {
+			if err := Interrupted(ctx); err != nil {
+				return nil, err
+			}
+			err := fn(i)
+			if Fatal(err) {
+				return nil, err
+			}
+			res[i] = err
+			if bail && Failed(err) {
+				return res[:i+1], nil
+			}
+		}
+		return res, nil
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		stop    = n
+		fatal   error
+		fatalAt = n
+	)
+
+	run := func(i int) {
+		err := recovering(fn, i)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case Fatal(err):
+			if i < fatalAt {
+				fatal, fatalAt = err, i
+			}
+			stop = 0
+		case bail && i < stop && Failed(err):
+			stop = i + 1
+		}
+		res[i] = err
+	}
+
+	for i := 0; i < n; i++ {
+		mu.Lock()
+		s := stop
+		mu.Unlock()
+		if i >= s {
+			break
+		}
+
+		if err := Interrupted(ctx); err != nil {
+			wg.Wait()
+			return nil, err
+		}
+
+		select {
+		case p.sem <- struct{}{}:
+			wg.Add(1)
+			go func(i int) {
+				defer func() {
+					<-p.sem
+					wg.Done()
+				}()
+				run(i)
+			}(i)
+		default:
+			run(i)
+		}
+	}
+
+	wg.Wait()
+
+	if fatal != nil {
+		return nil, fatal
+	}
+
+	if bail {
+		for i, err := range res {
+			if Failed(err) {
+				return res[:i+1], nil
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// recovering calls fn recovering its Go panic, a panic in a goroutine cannot
+// be recovered by the caller of Each.
+func recovering(fn func(int) error, i int) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = Recovered(r)
+		}
+	}()
+	return fn(i)
+}
@@ -0,0 +1,91 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+func TestEach(t *testing.T) {
+	errOdd := errors.New("odd")
+	odd := func(i int) error {
+		if i%2 == 1 {
+			return errOdd
+		}
+		return nil
+	}
+
+	t.Run("Sequential", func(t *testing.T) {
+		res, err := validation.Each(context.Background(), 4, false, odd)
+		require.Nil(t, err)
+		require.Equal(t, []error{nil, errOdd, nil, errOdd}, res)
+	})
+	t.Run("ParallelKeepsOrder", func(t *testing.T) {
+		ctx := validation.WithWorkers(context.Background(), 4)
+		res, err := validation.Each(ctx, 8, false, func(i int) error {
+			time.Sleep(time.Duration(8-i) * time.Millisecond)
+			return odd(i)
+		})
+		require.Nil(t, err)
+		require.Equal(t, []error{
+			nil, errOdd, nil, errOdd, nil, errOdd, nil, errOdd,
+		}, res)
+	})
+	t.Run("ParallelBail", func(t *testing.T) {
+		ctx := validation.WithWorkers(context.Background(), 4)
+		res, err := validation.Each(ctx, 8, true, odd)
+		require.Nil(t, err)
+		require.Equal(t, []error{nil, errOdd}, res)
+	})
+	t.Run("ParallelBounded", func(t *testing.T) {
+		var cur, max int32
+		ctx := validation.WithWorkers(context.Background(), 2)
+		_, err := validation.Each(ctx, 16, false, func(i int) error {
+			n := atomic.AddInt32(&cur, 1)
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&cur, -1)
+			return nil
+		})
+		require.Nil(t, err)
+		require.LessOrEqual(t, max, int32(3))
+	})
+	t.Run("PanicCancels", func(t *testing.T) {
+		var calls int32
+		ctx := validation.WithWorkers(context.Background(), 1)
+		_, err := validation.Each(ctx, 100, false, func(i int) error {
+			atomic.AddInt32(&calls, 1)
+			if i == 0 {
+				return validation.Panic{Err: errOdd}
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+		require.Equal(t, validation.Panic{Err: errOdd}, err)
+		require.Less(t, atomic.LoadInt32(&calls), int32(100))
+	})
+}
+
+func TestPlanParallel(t *testing.T) {
+	for _, mode := range []validation.Mode{validation.ModeAll, validation.ModeBailField} {
+		seq := validation.WithMode(context.Background(), mode)
+		par := validation.WithWorkers(seq, 4)
+		for v := range addressFixtures {
+			v := v
+			require.Equal(t,
+				validation.Validate(seq, addressRule, &v),
+				validation.Validate(par, addressRule, &v))
+		}
+	}
+}
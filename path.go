@@ -0,0 +1,206 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var errStop = errors.New("stop")
+
+// String formats the path in the dot/bracket notation, e.g.
+// addresses[2].zipCode or labels["env"].
+func (p Path) String() string {
+	var b strings.Builder
+	for i, s := range p {
+		switch s.Kind {
+		case IndexSegment:
+			fmt.Fprintf(&b, "[%d]", s.Index)
+		case KeySegment:
+			if k, ok := s.Key.(string); ok {
+				fmt.Fprintf(&b, "[%q]", k)
+			} else {
+				fmt.Fprintf(&b, "[%v]", s.Key)
+			}
+		default:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(s.Field)
+		}
+	}
+	return b.String()
+}
+
+// ParsePath parses a path in the dot/bracket notation. Numbers in brackets
+// are parsed as slice indexes, quoted strings as map keys. A leading dot is
+// allowed.
+func ParsePath(s string) (Path, error) {
+	var p Path
+
+	rest := strings.TrimPrefix(s, ".")
+	for rest != "" {
+		switch rest[0] {
+		case '[':
+			end := closing(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unclosed bracket", s)
+			}
+			seg, err := parseBracket(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %v", s, err)
+			}
+			p = append(p, seg)
+			rest = rest[end+1:]
+			if rest != "" && rest[0] != '.' && rest[0] != '[' {
+				return nil, fmt.Errorf("invalid path %q", s)
+			}
+		case '.':
+			if len(p) == 0 || len(rest) == 1 || rest[1] == '.' || rest[1] == '[' {
+				return nil, fmt.Errorf("invalid path %q: empty field", s)
+			}
+			rest = rest[1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			p = append(p, AtField(rest[:end]))
+			rest = rest[end:]
+		}
+	}
+
+	return p, nil
+}
+
+// closing returns the index of the bracket closing the bracket at s[0].
+func closing(s string) int {
+	if len(s) > 1 && s[1] == '"' {
+		for i := 2; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				if i+1 < len(s) && s[i+1] == ']' {
+					return i + 1
+				}
+				return -1
+			}
+		}
+		return -1
+	}
+	return strings.IndexByte(s, ']')
+}
+
+func parseBracket(s string) (Segment, error) {
+	if strings.HasPrefix(s, `"`) {
+		k, err := strconv.Unquote(s)
+		if err != nil {
+			return Segment{}, err
+		}
+		return AtKey(k), nil
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return Segment{}, fmt.Errorf("invalid index %q", s)
+	}
+	return AtIndex(i), nil
+}
+
+// Equal reports whether the paths are equal. An index segment equals to a
+// key segment with the same integer key, as they have the same notation.
+func (p Path) Equal(o Path) bool {
+	return len(p) == len(o) && p.HasPrefix(o)
+}
+
+// HasPrefix reports whether the path begins with the prefix.
+func (p Path) HasPrefix(prefix Path) bool {
+	if len(prefix) > len(p) {
+		return false
+	}
+	for i, s := range prefix {
+		if !s.equal(p[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Segment) equal(o Segment) bool {
+	switch {
+	case s.Kind == FieldSegment || o.Kind == FieldSegment:
+		return s.Kind == o.Kind && s.Field == o.Field
+	case s.Kind == IndexSegment && o.Kind == IndexSegment:
+		return s.Index == o.Index
+	case s.Kind == IndexSegment:
+		return intKey(o.Key, s.Index)
+	case o.Kind == IndexSegment:
+		return intKey(s.Key, o.Index)
+	default:
+		return s.Key == o.Key
+	}
+}
+
+func intKey(k interface{}, i int) bool {
+	v := reflect.ValueOf(k)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == int64(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return i >= 0 && v.Uint() == uint64(i)
+	default:
+		return false
+	}
+}
+
+// ErrorsAt returns the leaf errors of the tree err located exactly at the
+// path provided.
+func ErrorsAt(err error, path Path) []error {
+	var errs []error
+	Walk(err, func(p Path, leaf error) error {
+		if p.Equal(path) {
+			errs = append(errs, leaf)
+		}
+		return nil
+	})
+	return errs
+}
+
+// HasErrors reports whether the tree err has errors at the path provided or
+// under it.
+func HasErrors(err error, path Path) bool {
+	return FirstError(err, path) != nil
+}
+
+// FirstError returns the first leaf error of the tree err located under the
+// prefix provided or nil.
+func FirstError(err error, prefix Path) error {
+	var first error
+	Walk(err, func(p Path, leaf error) error {
+		if p.HasPrefix(prefix) {
+			first = leaf
+			return errStop
+		}
+		return nil
+	})
+	return first
+}
+
+// LeafPaths returns the paths of all the leaf errors of the tree err. Every
+// path is returned once in the order of the first occurrence.
+func LeafPaths(err error) []Path {
+	var paths []Path
+	Walk(err, func(p Path, _ error) error {
+		for _, q := range paths {
+			if q.Equal(p) {
+				return nil
+			}
+		}
+		paths = append(paths, p)
+		return nil
+	})
+	return paths
+}
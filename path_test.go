@@ -0,0 +1,112 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+func TestPathString(t *testing.T) {
+	p := validation.Path{
+		validation.AtField("addresses"),
+		validation.AtIndex(2),
+		validation.AtField("zipCode"),
+	}
+	require.Equal(t, "addresses[2].zipCode", p.String())
+
+	p = validation.Path{validation.AtField("labels"), validation.AtKey("env"), validation.AtKey(1)}
+	require.Equal(t, `labels["env"][1]`, p.String())
+
+	require.Equal(t, "", validation.Path(nil).String())
+}
+
+func TestParsePath(t *testing.T) {
+	cases := map[string]validation.Path{
+		"email": {validation.AtField("email")},
+		"addresses[2].zipCode": {
+			validation.AtField("addresses"),
+			validation.AtIndex(2),
+			validation.AtField("zipCode"),
+		},
+		".addresses[2].zipCode": {
+			validation.AtField("addresses"),
+			validation.AtIndex(2),
+			validation.AtField("zipCode"),
+		},
+		`labels["a.b]"][0]`: {
+			validation.AtField("labels"),
+			validation.AtKey("a.b]"),
+			validation.AtIndex(0),
+		},
+		"[1]": {validation.AtIndex(1)},
+		"":    nil,
+	}
+
+	for s, exp := range cases {
+		p, err := validation.ParsePath(s)
+		require.NoError(t, err, s)
+		require.Equal(t, exp, p, s)
+	}
+
+	for _, s := range []string{"a..b", "a.", "a[", "a[x]", "a[1]b", `a["x]`, "a.[1]"} {
+		_, err := validation.ParsePath(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestPathRoundTrip(t *testing.T) {
+	for _, p := range validation.LeafPaths(errTree) {
+		q, err := validation.ParsePath(p.String())
+		require.NoError(t, err)
+		require.True(t, p.Equal(q), p.String())
+	}
+}
+
+func mustPath(t *testing.T, s string) validation.Path {
+	p, err := validation.ParsePath(s)
+	require.NoError(t, err)
+	return p
+}
+
+func TestErrorsAt(t *testing.T) {
+	require.Equal(t,
+		[]error{errors.New("invalid")},
+		validation.ErrorsAt(errTree, mustPath(t, "addresses[2].zipCode")))
+	require.Empty(t, validation.ErrorsAt(errTree, mustPath(t, "addresses[2]")))
+	require.Len(t, validation.ErrorsAt(errTree, mustPath(t, `labels["env"]`)), 1)
+}
+
+func TestHasErrors(t *testing.T) {
+	require.True(t, validation.HasErrors(errTree, mustPath(t, "addresses[2]")))
+	require.True(t, validation.HasErrors(errTree, mustPath(t, "email")))
+	require.False(t, validation.HasErrors(errTree, mustPath(t, "addresses[1]")))
+	require.True(t, validation.HasErrors(errTree, nil))
+	require.False(t, validation.HasErrors(nil, nil))
+}
+
+func TestFirstError(t *testing.T) {
+	require.Equal(t,
+		validation.Error{Code: "str.required", Message: "blank"},
+		validation.FirstError(errTree, nil))
+	require.Equal(t, errors.New("invalid"), validation.FirstError(errTree, mustPath(t, "addresses")))
+	require.Nil(t, validation.FirstError(errTree, mustPath(t, "name")))
+}
+
+func TestLeafPaths(t *testing.T) {
+	paths := validation.LeafPaths(errTree)
+	strs := make([]string, len(paths))
+	for i, p := range paths {
+		strs[i] = p.String()
+	}
+	require.Equal(t, []string{"email", "addresses[2].zipCode", `labels["env"]`}, strs)
+}
+
+func TestPathIntKey(t *testing.T) {
+	tree := validation.Errors{
+		validation.MapError{Key: 7, Errors: validation.Errors{errors.New("bad")}},
+	}
+	require.True(t, validation.HasErrors(tree, mustPath(t, "[7]")))
+}
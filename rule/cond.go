@@ -0,0 +1,156 @@
+package rule
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+var (
+	// ParamCondField is the name of the field a rule depends on.
+	ParamCondField = "field"
+	// ParamCondValue is the value of the field a rule depends on.
+	ParamCondValue = "value"
+	// ParamCondFields is the names of the fields a rule depends on.
+	ParamCondFields = "fields"
+)
+
+// present reports whether the value pointed by p is set, i.e. it is not zero
+// and not an empty slice or map.
+func present(p interface{}) bool {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return false
+	}
+	v = v.Elem()
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() > 0
+	default:
+		return !v.IsZero()
+	}
+}
+
+// Present is a Cond which holds if the value validated is set.
+func Present(ctx, v interface{}) bool {
+	return present(v)
+}
+
+// FieldPresent creates a Cond which holds if the struct field pointed by attr
+// is set.
+func FieldPresent(attr validation.Attr) validation.Cond {
+	return func(ctx, v interface{}) bool {
+		return present(attr(v))
+	}
+}
+
+// FieldEquals creates a Cond which holds if the struct field pointed by attr
+// equals to value.
+func FieldEquals(attr validation.Attr, value interface{}) validation.Cond {
+	return func(ctx, v interface{}) bool {
+		p := reflect.ValueOf(attr(v))
+		return p.Kind() == reflect.Ptr && !p.IsNil() && p.Elem().Interface() == value
+	}
+}
+
+func structrule(fn func(ctx, v interface{}) error) validation.Rule {
+	return func(ctx interface{}) func(interface{}) error {
+		return func(v interface{}) error {
+			t := reflect.TypeOf(v)
+			if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+				return unexpectedType(v)
+			}
+			return fn(ctx, v)
+		}
+	}
+}
+
+func names(ctx, v interface{}, attrs []validation.Attr) []string {
+	ns := make([]string, len(attrs))
+	for i, a := range attrs {
+		ns[i] = pathName(validation.FieldPath(ctx, v, a))
+	}
+	return ns
+}
+
+func pathName(path []string) string {
+	return strings.Join(path, ".")
+}
+
+// RequiredIf creates a struct level validator to check whether the field
+// pointed by target is set if the field pointed by other equals to value. The
+// error is reported under the target field.
+func RequiredIf(target, other validation.Attr, value interface{}, msg string) validation.Rule {
+	cond := FieldEquals(other, value)
+	return describe(structrule(func(ctx, v interface{}) error {
+		if !cond(ctx, v) || present(target(v)) {
+			return nil
+		}
+		return validation.FieldError(ctx, v, target, validation.Error{
+			Code:    NameRequiredIf,
+			Message: msg,
+			Params: validation.Params{
+				ParamCondField: pathName(validation.FieldPath(ctx, v, other)),
+				ParamCondValue: value,
+			},
+		})
+	}), NameRequiredIf, msg, validation.Params{ParamCondValue: value})
+}
+
+// RequiredWith creates a struct level validator to check whether the field
+// pointed by target is set if any of the others fields is set.
+func RequiredWith(target validation.Attr, others []validation.Attr, msg string) validation.Rule {
+	return dependent(NameRequiredWith, target, others, msg, func(v interface{}) bool {
+		return anyPresent(v, others) && !present(target(v))
+	})
+}
+
+// RequiredWithout creates a struct level validator to check whether the field
+// pointed by target is set if any of the others fields is not set.
+func RequiredWithout(target validation.Attr, others []validation.Attr, msg string) validation.Rule {
+	return dependent(NameRequiredWithout, target, others, msg, func(v interface{}) bool {
+		return !allPresent(v, others) && !present(target(v))
+	})
+}
+
+// ExcludedWith creates a struct level validator to check whether the field
+// pointed by target is not set if any of the others fields is set.
+func ExcludedWith(target validation.Attr, others []validation.Attr, msg string) validation.Rule {
+	return dependent(NameExcludedWith, target, others, msg, func(v interface{}) bool {
+		return anyPresent(v, others) && present(target(v))
+	})
+}
+
+func dependent(name string, target validation.Attr, others []validation.Attr, msg string, fails func(interface{}) bool) validation.Rule {
+	return describe(structrule(func(ctx, v interface{}) error {
+		if !fails(v) {
+			return nil
+		}
+		return validation.FieldError(ctx, v, target, validation.Error{
+			Code:    name,
+			Message: msg,
+			Params: validation.Params{
+				ParamCondFields: names(ctx, v, others),
+			},
+		})
+	}), name, msg, nil)
+}
+
+func anyPresent(v interface{}, attrs []validation.Attr) bool {
+	for _, a := range attrs {
+		if present(a(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+func allPresent(v interface{}, attrs []validation.Attr) bool {
+	for _, a := range attrs {
+		if !present(a(v)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package rule_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+type Shipment struct {
+	Country string  `json:"country"`
+	ZipCode string  `json:"zipCode"`
+	Phone   string  `json:"phone"`
+	Email   string  `json:"email"`
+	Pickup  *string `json:"pickup"`
+}
+
+func country(v interface{}) interface{} { return &v.(*Shipment).Country }
+func zipCode(v interface{}) interface{} { return &v.(*Shipment).ZipCode }
+func phone(v interface{}) interface{}   { return &v.(*Shipment).Phone }
+func email(v interface{}) interface{}   { return &v.(*Shipment).Email }
+func pickup(v interface{}) interface{}  { return &v.(*Shipment).Pickup }
+
+func self(v interface{}) interface{} { return v }
+
+func shipmentRule(rules ...validation.Rule) func(interface{}) error {
+	return validation.Struct(&Shipment{}, "json", []validation.Field{
+		{Attr: self, Rules: rules},
+	})(nil)
+}
+
+func fieldError(name string, err error) validation.Errors {
+	return validation.Errors([]error{
+		validation.StructError{Field: name, Errors: []error{err}},
+	})
+}
+
+func TestRequiredIf(t *testing.T) {
+	msg := "ErrRequired"
+	fun := shipmentRule(rule.RequiredIf(zipCode, country, "US", msg))
+
+	t.Run("PanicIfNotStruct", func(t *testing.T) {
+		v := ""
+		assertPanic(t, rule.RequiredIf(zipCode, country, "US", msg)(nil)(&v))
+	})
+	t.Run("ErrorIfCondHolds", func(t *testing.T) {
+		exp := fieldError("zipCode", validation.Error{
+			Code:    rule.NameRequiredIf,
+			Message: msg,
+			Params: validation.Params{
+				rule.ParamCondField: "country",
+				rule.ParamCondValue: "US",
+			},
+		})
+		require.Equal(t, exp, fun(&Shipment{Country: "US"}))
+	})
+	t.Run("ErrorIfCondHoldsInCustomContext", func(t *testing.T) {
+		r := validation.Struct(&Shipment{}, "json", []validation.Field{
+			{Attr: self, Rules: []validation.Rule{rule.RequiredIf(zipCode, country, "US", msg)}},
+		})
+		err := r([]string{"a"})(&Shipment{Country: "US"})
+		require.Equal(t, "zipCode", err.(validation.Errors)[0].(validation.StructError).Field)
+	})
+	t.Run("OkIfSet", func(t *testing.T) {
+		require.Nil(t, fun(&Shipment{Country: "US", ZipCode: "123"}))
+	})
+	t.Run("OkIfCondDoesNotHold", func(t *testing.T) {
+		require.Nil(t, fun(&Shipment{Country: "RU"}))
+	})
+}
+
+func TestRequiredWith(t *testing.T) {
+	msg := "ErrRequired"
+	fun := shipmentRule(rule.RequiredWith(zipCode, []validation.Attr{country}, msg))
+
+	t.Run("ErrorIfOtherSet", func(t *testing.T) {
+		exp := fieldError("zipCode", validation.Error{
+			Code:    rule.NameRequiredWith,
+			Message: msg,
+			Params: validation.Params{
+				rule.ParamCondFields: []string{"country"},
+			},
+		})
+		require.Equal(t, exp, fun(&Shipment{Country: "US"}))
+	})
+	t.Run("OkIfOtherNotSet", func(t *testing.T) {
+		require.Nil(t, fun(&Shipment{}))
+	})
+}
+
+func TestRequiredWithout(t *testing.T) {
+	msg := "ErrRequired"
+	fun := shipmentRule(rule.RequiredWithout(phone, []validation.Attr{email}, msg))
+
+	t.Run("ErrorIfOtherNotSet", func(t *testing.T) {
+		exp := fieldError("phone", validation.Error{
+			Code:    rule.NameRequiredWithout,
+			Message: msg,
+			Params: validation.Params{
+				rule.ParamCondFields: []string{"email"},
+			},
+		})
+		require.Equal(t, exp, fun(&Shipment{}))
+	})
+	t.Run("OkIfOtherSet", func(t *testing.T) {
+		require.Nil(t, fun(&Shipment{Email: "user@mail.com"}))
+	})
+	t.Run("OkIfSet", func(t *testing.T) {
+		require.Nil(t, fun(&Shipment{Phone: "123"}))
+	})
+}
+
+func TestExcludedWith(t *testing.T) {
+	msg := "ErrExcluded"
+	fun := shipmentRule(rule.ExcludedWith(pickup, []validation.Attr{zipCode}, msg))
+	point := "A1"
+
+	t.Run("ErrorIfBothSet", func(t *testing.T) {
+		exp := fieldError("pickup", validation.Error{
+			Code:    rule.NameExcludedWith,
+			Message: msg,
+			Params: validation.Params{
+				rule.ParamCondFields: []string{"zipCode"},
+			},
+		})
+		require.Equal(t, exp, fun(&Shipment{ZipCode: "123", Pickup: &point}))
+	})
+	t.Run("OkIfOtherNotSet", func(t *testing.T) {
+		require.Nil(t, fun(&Shipment{Pickup: &point}))
+	})
+}
+
+func TestPresent(t *testing.T) {
+	fun := validation.When(rule.Present, rule.StrEmail("ErrEmail"))(nil)
+
+	t.Run("ErrorIfPresentAndInvalid", func(t *testing.T) {
+		v := "user"
+		require.Error(t, fun(&v))
+	})
+	t.Run("OkIfNotPresent", func(t *testing.T) {
+		v := ""
+		require.Nil(t, fun(&v))
+	})
+}
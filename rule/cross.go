@@ -0,0 +1,166 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+var (
+	eIncomparable = errors.New("fields should have same comparable type")
+)
+
+var (
+	// ParamCrossField is the name of the field compared with.
+	ParamCrossField = "field"
+)
+
+// pair checks that a and b point to values of the same type and returns the
+// values.
+func pair(a, b interface{}) (reflect.Value, reflect.Value, error) {
+	va := reflect.ValueOf(a)
+	vb := reflect.ValueOf(b)
+	if va.Kind() != reflect.Ptr || va.Type() != vb.Type() {
+		return va, vb, eIncomparable
+	}
+	return va.Elem(), vb.Elem(), nil
+}
+
+// equal reports whether values pointed by a and b are equal. The values
+// might have any comparable type, times are equal if they are the same
+// instant and NaN does not equal to anything.
+func equal(a, b interface{}) (bool, error) {
+	va, vb, err := pair(a, b)
+	if err != nil {
+		return false, err
+	}
+
+	if t, ok := va.Interface().(time.Time); ok {
+		return t.Equal(vb.Interface().(time.Time)), nil
+	}
+
+	if !va.Comparable() || !vb.Comparable() {
+		return false, eIncomparable
+	}
+	return va.Interface() == vb.Interface(), nil
+}
+
+// compare compares values pointed by a and b, it returns -1, 0 or 1. It
+// returns false if the values are unordered, i.e. one of them is NaN.
+func compare(a, b interface{}) (int, bool, error) {
+	va, vb, err := pair(a, b)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if t, ok := va.Interface().(time.Time); ok {
+		return t.Compare(vb.Interface().(time.Time)), true, nil
+	}
+
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp(va.Int() < vb.Int(), va.Int() > vb.Int()), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp(va.Uint() < vb.Uint(), va.Uint() > vb.Uint()), true, nil
+	case reflect.Float32, reflect.Float64:
+		x, y := va.Float(), vb.Float()
+		if math.IsNaN(x) || math.IsNaN(y) {
+			return 0, false, nil
+		}
+		return cmp(x < y, x > y), true, nil
+	case reflect.String:
+		return cmp(va.String() < vb.String(), va.String() > vb.String()), true, nil
+	default:
+		return 0, false, eIncomparable
+	}
+}
+
+func cmp(lt, gt bool) int {
+	switch {
+	case lt:
+		return -1
+	case gt:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ordered creates a test of crossrule checking the order of the values,
+// unordered values fail the test.
+func ordered(ok func(int) bool) func(a, b interface{}) (bool, error) {
+	return func(a, b interface{}) (bool, error) {
+		c, ordered, err := compare(a, b)
+		return ordered && ok(c), err
+	}
+}
+
+func crossrule(name string, target, other validation.Attr, msg string, test func(a, b interface{}) (bool, error)) validation.Rule {
+	return describe(structrule(func(ctx, v interface{}) error {
+		ok, err := test(target(v), other(v))
+		if err != nil {
+			return validation.Panic{Err: fmt.Errorf("%v: %v", eIncomparable, reflect.TypeOf(target(v)))}
+		}
+		if ok {
+			return nil
+		}
+		return validation.FieldError(ctx, v, target, validation.Error{
+			Code:    name,
+			Message: msg,
+			Params: validation.Params{
+				ParamCrossField: pathName(validation.FieldPath(ctx, v, other)),
+			},
+		})
+	}), name, msg, nil)
+}
+
+// EqField creates a struct level validator to check whether the field pointed
+// by target equals to the field pointed by other. The fields might have any
+// comparable type. The error is reported under the target field.
+func EqField(target, other validation.Attr, msg string) validation.Rule {
+	return crossrule(NameEqField, target, other, msg, equal)
+}
+
+// NeField creates a struct level validator to check whether the field pointed
+// by target does not equal to the field pointed by other.
+func NeField(target, other validation.Attr, msg string) validation.Rule {
+	return crossrule(NameNeField, target, other, msg, func(a, b interface{}) (bool, error) {
+		eq, err := equal(a, b)
+		return !eq, err
+	})
+}
+
+// LtField creates a struct level validator to check whether the field pointed
+// by target is less than the field pointed by other.
+func LtField(target, other validation.Attr, msg string) validation.Rule {
+	return crossrule(NameLtField, target, other, msg, ordered(func(c int) bool { return c < 0 }))
+}
+
+// LteField creates a struct level validator to check whether the field
+// pointed by target is not great than the field pointed by other.
+func LteField(target, other validation.Attr, msg string) validation.Rule {
+	return crossrule(NameLteField, target, other, msg, ordered(func(c int) bool { return c <= 0 }))
+}
+
+// GtField creates a struct level validator to check whether the field pointed
+// by target is great than the field pointed by other.
+func GtField(target, other validation.Attr, msg string) validation.Rule {
+	return crossrule(NameGtField, target, other, msg, ordered(func(c int) bool { return c > 0 }))
+}
+
+// GteField creates a struct level validator to check whether the field
+// pointed by target is not less than the field pointed by other.
+func GteField(target, other validation.Attr, msg string) validation.Rule {
+	return crossrule(NameGteField, target, other, msg, ordered(func(c int) bool { return c >= 0 }))
+}
+
+// DateRange creates a struct level validator to check whether the time
+// pointed by start is before the time pointed by end. The error is reported
+// under the end field.
+func DateRange(start, end validation.Attr, msg string) validation.Rule {
+	return crossrule(NameDateRange, end, start, msg, ordered(func(c int) bool { return c > 0 }))
+}
@@ -0,0 +1,160 @@
+package rule_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+type Booking struct {
+	Password     string    `json:"password"`
+	Confirmation string    `json:"confirmation"`
+	Guests       int       `json:"guests"`
+	Rooms        int       `json:"rooms"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+}
+
+func password(v interface{}) interface{}     { return &v.(*Booking).Password }
+func confirmation(v interface{}) interface{} { return &v.(*Booking).Confirmation }
+func guests(v interface{}) interface{}       { return &v.(*Booking).Guests }
+func rooms(v interface{}) interface{}        { return &v.(*Booking).Rooms }
+func start(v interface{}) interface{}        { return &v.(*Booking).Start }
+func end(v interface{}) interface{}          { return &v.(*Booking).End }
+
+func bookingRule(r validation.Rule) func(interface{}) error {
+	return validation.Struct(&Booking{}, "json", []validation.Field{
+		{Attr: self, Rules: []validation.Rule{r}},
+	})(nil)
+}
+
+func crossError(field, other, code, msg string) validation.Errors {
+	return fieldError(field, validation.Error{
+		Code:    code,
+		Message: msg,
+		Params:  validation.Params{rule.ParamCrossField: other},
+	})
+}
+
+func TestEqField(t *testing.T) {
+	msg := "ErrEq"
+	fun := bookingRule(rule.EqField(confirmation, password, msg))
+
+	t.Run("PanicIfTypesDiffer", func(t *testing.T) {
+		assertPanic(t, bookingRule(rule.EqField(password, guests, msg))(&Booking{}))
+	})
+	t.Run("ErrorIfNotEqual", func(t *testing.T) {
+		exp := crossError("confirmation", "password", rule.NameEqField, msg)
+		require.Equal(t, exp, fun(&Booking{Password: "1", Confirmation: "2"}))
+	})
+	t.Run("OkIfEqual", func(t *testing.T) {
+		require.Nil(t, fun(&Booking{Password: "1", Confirmation: "1"}))
+	})
+}
+
+func TestNeField(t *testing.T) {
+	msg := "ErrNe"
+	fun := bookingRule(rule.NeField(confirmation, password, msg))
+
+	t.Run("ErrorIfEqual", func(t *testing.T) {
+		exp := crossError("confirmation", "password", rule.NameNeField, msg)
+		require.Equal(t, exp, fun(&Booking{Password: "1", Confirmation: "1"}))
+	})
+	t.Run("OkIfNotEqual", func(t *testing.T) {
+		require.Nil(t, fun(&Booking{Password: "1", Confirmation: "2"}))
+	})
+}
+
+type Pair struct {
+	Flag  bool    `json:"flag"`
+	Check bool    `json:"check"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Tags  []int   `json:"tags"`
+	Other []int   `json:"other"`
+}
+
+func pairRule(r validation.Rule) func(interface{}) error {
+	return validation.Struct(&Pair{}, "json", []validation.Field{
+		{Attr: self, Rules: []validation.Rule{r}},
+	})(nil)
+}
+
+func TestEqFieldTypes(t *testing.T) {
+	msg := "ErrEq"
+	flag := func(v interface{}) interface{} { return &v.(*Pair).Flag }
+	check := func(v interface{}) interface{} { return &v.(*Pair).Check }
+	x := func(v interface{}) interface{} { return &v.(*Pair).X }
+	y := func(v interface{}) interface{} { return &v.(*Pair).Y }
+	tags := func(v interface{}) interface{} { return &v.(*Pair).Tags }
+	other := func(v interface{}) interface{} { return &v.(*Pair).Other }
+
+	t.Run("Bool", func(t *testing.T) {
+		eq := pairRule(rule.EqField(check, flag, msg))
+		require.Nil(t, eq(&Pair{Flag: true, Check: true}))
+		require.Equal(t, crossError("check", "flag", rule.NameEqField, msg),
+			eq(&Pair{Flag: true}))
+
+		ne := pairRule(rule.NeField(check, flag, msg))
+		require.Nil(t, ne(&Pair{Flag: true}))
+	})
+	t.Run("NaN", func(t *testing.T) {
+		nan := math.NaN()
+		require.Error(t, pairRule(rule.EqField(y, x, msg))(&Pair{X: nan, Y: nan}))
+		require.Nil(t, pairRule(rule.NeField(y, x, msg))(&Pair{X: nan, Y: nan}))
+		require.Error(t, pairRule(rule.LteField(y, x, msg))(&Pair{X: nan, Y: 1}))
+		require.Error(t, pairRule(rule.GteField(y, x, msg))(&Pair{X: 1, Y: nan}))
+	})
+	t.Run("PanicIfNotComparable", func(t *testing.T) {
+		assertPanic(t, pairRule(rule.EqField(tags, other, msg))(&Pair{}))
+		assertPanic(t, pairRule(rule.LtField(check, flag, msg))(&Pair{}))
+	})
+}
+
+func TestCompareFields(t *testing.T) {
+	msg := "ErrCmp"
+	cases := []struct {
+		name string
+		code string
+		rule validation.Rule
+		ok   []int
+		fail []int
+	}{
+		{"Lt", rule.NameLtField, rule.LtField(rooms, guests, msg), []int{1}, []int{2, 3}},
+		{"Lte", rule.NameLteField, rule.LteField(rooms, guests, msg), []int{1, 2}, []int{3}},
+		{"Gt", rule.NameGtField, rule.GtField(rooms, guests, msg), []int{3}, []int{1, 2}},
+		{"Gte", rule.NameGteField, rule.GteField(rooms, guests, msg), []int{2, 3}, []int{1}},
+	}
+
+	for _, c := range cases {
+		fun := bookingRule(c.rule)
+		t.Run(c.name, func(t *testing.T) {
+			for _, n := range c.ok {
+				require.Nil(t, fun(&Booking{Guests: 2, Rooms: n}))
+			}
+			for _, n := range c.fail {
+				exp := crossError("rooms", "guests", c.code, msg)
+				require.Equal(t, exp, fun(&Booking{Guests: 2, Rooms: n}))
+			}
+		})
+	}
+}
+
+func TestDateRange(t *testing.T) {
+	msg := "ErrRange"
+	fun := bookingRule(rule.DateRange(start, end, msg))
+	now := time.Now()
+
+	t.Run("ErrorIfEndBeforeStart", func(t *testing.T) {
+		exp := crossError("end", "start", rule.NameDateRange, msg)
+		require.Equal(t, exp, fun(&Booking{Start: now, End: now.Add(-time.Hour)}))
+	})
+	t.Run("OkIfStartBeforeEnd", func(t *testing.T) {
+		require.Nil(t, fun(&Booking{Start: now, End: now.Add(time.Hour)}))
+	})
+}
@@ -0,0 +1,65 @@
+package rule
+
+import (
+	"github.com/vbogretsov/go-validation"
+)
+
+// Names of the rules described by the package. The names are the codes of
+// the rule errors as well.
+const (
+	NameNotNil   = "generic.not_nil"
+	NameIn       = "generic.in"
+	NameRequired = "generic.required"
+	NameOptional = "generic.optional"
+
+	NameStrRequired  = "str.required"
+	NameStrLen       = "str.len"
+	NameStrMinLen    = "str.min_len"
+	NameStrMaxLen    = "str.max_len"
+	NameStrMatch     = "str.match"
+	NameStrEmail     = "str.email"
+	NameStrIPv4      = "str.ipv4"
+	NameStrIPv6      = "str.ipv6"
+	NameStrIP        = "str.ip"
+	NameStrURL       = "str.url"
+	NameStrUpperCase = "str.upper_case"
+	NameStrLowerCase = "str.lower_case"
+	NameStrJSON      = "str.json"
+
+	NameNumMin     = "num.min"
+	NameNumMax     = "num.max"
+	NameNumBetween = "num.between"
+
+	NameSliceLen    = "slice.len"
+	NameSliceMinLen = "slice.min_len"
+	NameSliceMaxLen = "slice.max_len"
+	NameSliceEach   = "slice.each"
+	NameSliceUnique = "slice.unique"
+
+	NameMapLen           = "map.len"
+	NameMapMinLen        = "map.min_len"
+	NameMapMaxLen        = "map.max_len"
+	NameMapEach          = "map.each"
+	NameMapRequiredKeys  = "map.required_keys"
+	NameMapForbiddenKeys = "map.forbidden_keys"
+
+	NameRequiredIf      = "cond.required_if"
+	NameRequiredWith    = "cond.required_with"
+	NameRequiredWithout = "cond.required_without"
+	NameExcludedWith    = "cond.excluded_with"
+
+	NameEqField   = "cross.eq_field"
+	NameNeField   = "cross.ne_field"
+	NameLtField   = "cross.lt_field"
+	NameLteField  = "cross.lte_field"
+	NameGtField   = "cross.gt_field"
+	NameGteField  = "cross.gte_field"
+	NameDateRange = "cross.date_range"
+)
+
+// describe attaches a descriptor without child rules to r.
+func describe(r validation.Rule, name, msg string, params validation.Params) validation.Rule {
+	return validation.Described(r, func() validation.Descriptor {
+		return validation.Descriptor{Name: name, Message: msg, Params: params}
+	})
+}
@@ -0,0 +1,148 @@
+package rule_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+func TestDescribe(t *testing.T) {
+	cases := map[string]struct {
+		rule validation.Rule
+		want validation.Descriptor
+	}{
+		"StrLen": {
+			rule: rule.StrLen(1, 5, "msg"),
+			want: validation.Descriptor{Name: rule.NameStrLen, Message: "msg", Params: validation.Params{
+				rule.ParamStrMinLen: 1,
+				rule.ParamStrMaxLen: 5,
+			}},
+		},
+		"StrMatch": {
+			rule: rule.StrMatch(regexp.MustCompile(`^\d+$`), "msg"),
+			want: validation.Descriptor{Name: rule.NameStrMatch, Message: "msg", Params: validation.Params{
+				rule.ParamStrPattern: `^\d+$`,
+			}},
+		},
+		"StrEmail": {
+			rule: rule.StrEmail("msg"),
+			want: validation.Descriptor{Name: rule.NameStrEmail, Message: "msg"},
+		},
+		"Between": {
+			rule: rule.Between(1, 5, "msg"),
+			want: validation.Descriptor{Name: rule.NameNumBetween, Message: "msg", Params: validation.Params{
+				rule.ParamNumMin: 1,
+				rule.ParamNumMax: 5,
+			}},
+		},
+		"In": {
+			rule: rule.In([]interface{}{1, 2}, "msg"),
+			want: validation.Descriptor{Name: rule.NameIn, Message: "msg", Params: validation.Params{
+				rule.ParamInSupported: []interface{}{1, 2},
+			}},
+		},
+		"SliceEach": {
+			rule: rule.SliceEach(userIter, []validation.Rule{rule.StrRequired("msg")}),
+			want: validation.Descriptor{
+				Name:  rule.NameSliceEach,
+				Items: []validation.Descriptor{{Name: rule.NameStrRequired, Message: "msg"}},
+			},
+		},
+		"MapEach": {
+			rule: rule.MapKeys([]validation.Rule{rule.StrRequired("msg")}),
+			want: validation.Descriptor{
+				Name:  rule.NameMapEach,
+				Keys:  []validation.Descriptor{{Name: rule.NameStrRequired, Message: "msg"}},
+				Items: []validation.Descriptor{},
+			},
+		},
+		"Optional": {
+			rule: rule.Optional(rule.Required("msg")),
+			want: validation.Descriptor{
+				Name:  rule.NameOptional,
+				Rules: []validation.Descriptor{{Name: rule.NameRequired, Message: "msg"}},
+			},
+		},
+		"RequiredIf": {
+			rule: rule.RequiredIf(zipCode, country, "NL", "msg"),
+			want: validation.Descriptor{Name: rule.NameRequiredIf, Message: "msg", Params: validation.Params{
+				rule.ParamCondValue: "NL",
+			}},
+		},
+		"DateRange": {
+			rule: rule.DateRange(start, end, "msg"),
+			want: validation.Descriptor{Name: rule.NameDateRange, Message: "msg"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, c.want, validation.Describe(c.rule))
+		})
+	}
+}
+
+var bookingsRule = validation.Struct(&Booking{}, "", []validation.Field{
+	{
+		Attr:  guests,
+		Rules: []validation.Rule{rule.Min(1, "no guests")},
+	},
+	{
+		Attr: self,
+		Rules: []validation.Rule{
+			rule.LteField(rooms, guests, "too many rooms"),
+			rule.DateRange(start, end, "invalid dates"),
+		},
+	},
+})
+
+func TestDescriptorWalk(t *testing.T) {
+	d := validation.Describe(rule.SliceEach(bookingIter, []validation.Rule{bookingsRule}))
+
+	var paths [][]string
+	var names []string
+	d.Walk(func(path []string, d validation.Descriptor) bool {
+		paths = append(paths, path)
+		names = append(names, d.Name)
+		return true
+	})
+
+	require.Equal(t, []string{
+		rule.NameSliceEach,
+		validation.NameStruct,
+		rule.NameLteField,
+		rule.NameDateRange,
+		rule.NameNumMin,
+	}, names)
+	require.Equal(t, [][]string{
+		nil,
+		{validation.SegmentItems},
+		{validation.SegmentItems},
+		{validation.SegmentItems},
+		{validation.SegmentItems, "Guests"},
+	}, paths)
+
+	require.Equal(t, []validation.Descriptor{
+		{Name: rule.NameDateRange, Message: "invalid dates"},
+	}, d.Find(rule.NameDateRange))
+}
+
+func TestDescriptorString(t *testing.T) {
+	d := validation.Describe(rule.SliceEach(bookingIter, []validation.Rule{bookingsRule}))
+	require.Equal(t, `slice.each
+  []:
+    struct
+      cross.lte_field "too many rooms"
+      cross.date_range "invalid dates"
+      Guests:
+        num.min min=1 "no guests"
+`, d.String())
+}
+
+func bookingIter(v interface{}, i int) interface{} {
+	return &(*(v.(*[]Booking)))[i]
+}
@@ -0,0 +1,56 @@
+package rule
+
+import (
+	"github.com/vbogretsov/go-validation"
+)
+
+// Sentinels matching errors of the package rules by their codes, e.g.
+//
+//	errors.Is(err, rule.ErrStrMinLen)
+var (
+	ErrNotNil   = validation.Code(NameNotNil)
+	ErrIn       = validation.Code(NameIn)
+	ErrRequired = validation.Code(NameRequired)
+
+	ErrStrRequired  = validation.Code(NameStrRequired)
+	ErrStrLen       = validation.Code(NameStrLen)
+	ErrStrMinLen    = validation.Code(NameStrMinLen)
+	ErrStrMaxLen    = validation.Code(NameStrMaxLen)
+	ErrStrMatch     = validation.Code(NameStrMatch)
+	ErrStrEmail     = validation.Code(NameStrEmail)
+	ErrStrIPv4      = validation.Code(NameStrIPv4)
+	ErrStrIPv6      = validation.Code(NameStrIPv6)
+	ErrStrIP        = validation.Code(NameStrIP)
+	ErrStrURL       = validation.Code(NameStrURL)
+	ErrStrUpperCase = validation.Code(NameStrUpperCase)
+	ErrStrLowerCase = validation.Code(NameStrLowerCase)
+	ErrStrJSON      = validation.Code(NameStrJSON)
+
+	ErrNumMin     = validation.Code(NameNumMin)
+	ErrNumMax     = validation.Code(NameNumMax)
+	ErrNumBetween = validation.Code(NameNumBetween)
+
+	ErrSliceLen    = validation.Code(NameSliceLen)
+	ErrSliceMinLen = validation.Code(NameSliceMinLen)
+	ErrSliceMaxLen = validation.Code(NameSliceMaxLen)
+	ErrSliceUnique = validation.Code(NameSliceUnique)
+
+	ErrMapLen           = validation.Code(NameMapLen)
+	ErrMapMinLen        = validation.Code(NameMapMinLen)
+	ErrMapMaxLen        = validation.Code(NameMapMaxLen)
+	ErrMapRequiredKeys  = validation.Code(NameMapRequiredKeys)
+	ErrMapForbiddenKeys = validation.Code(NameMapForbiddenKeys)
+
+	ErrRequiredIf      = validation.Code(NameRequiredIf)
+	ErrRequiredWith    = validation.Code(NameRequiredWith)
+	ErrRequiredWithout = validation.Code(NameRequiredWithout)
+	ErrExcludedWith    = validation.Code(NameExcludedWith)
+
+	ErrEqField   = validation.Code(NameEqField)
+	ErrNeField   = validation.Code(NameNeField)
+	ErrLtField   = validation.Code(NameLtField)
+	ErrLteField  = validation.Code(NameLteField)
+	ErrGtField   = validation.Code(NameGtField)
+	ErrGteField  = validation.Code(NameGteField)
+	ErrDateRange = validation.Code(NameDateRange)
+)
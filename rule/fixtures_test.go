@@ -97,14 +97,14 @@ var invalidUserErrors = validation.Errors([]error{
 			validation.StructError{
 				Field: "Email",
 				Errors: []error{
-					validation.Error{Message: eBlank},
-					validation.Error{Message: eEmail},
+					validation.Error{Code: rule.NameStrRequired, Message: eBlank},
+					validation.Error{Code: rule.NameStrEmail, Message: eEmail},
 				},
 			},
 			validation.StructError{
 				Field: "Password",
 				Errors: []error{
-					validation.Error{Message: eMinLen, Params: validation.Params{
+					validation.Error{Code: rule.NameStrMinLen, Message: eMinLen, Params: validation.Params{
 						rule.ParamStrMinLen: minLen,
 					}},
 				},
@@ -116,12 +116,12 @@ var invalidUserErrors = validation.Errors([]error{
 		Errors: []error{
 			validation.StructError{
 				Field:  "Email",
-				Errors: []error{validation.Error{Message: eEmail}},
+				Errors: []error{validation.Error{Code: rule.NameStrEmail, Message: eEmail}},
 			},
 			validation.StructError{
 				Field: "Password",
 				Errors: []error{
-					validation.Error{Message: eMinLen, Params: validation.Params{
+					validation.Error{Code: rule.NameStrMinLen, Message: eMinLen, Params: validation.Params{
 						rule.ParamStrMinLen: minLen,
 					}},
 				},
@@ -133,7 +133,7 @@ var invalidUserErrors = validation.Errors([]error{
 		Errors: []error{
 			validation.StructError{
 				Field:  "Email",
-				Errors: []error{validation.Error{Message: eEmail}},
+				Errors: []error{validation.Error{Code: rule.NameStrEmail, Message: eEmail}},
 			},
 		},
 	},
@@ -169,10 +169,10 @@ var duplicatedUsers = []User{
 var duplicatedUserErrors = validation.Errors([]error{
 	validation.SliceError{
 		Index:  3,
-		Errors: []error{validation.Error{Message: eDuplicate}},
+		Errors: []error{validation.Error{Code: rule.NameSliceUnique, Message: eDuplicate}},
 	},
 	validation.SliceError{
 		Index:  5,
-		Errors: []error{validation.Error{Message: eDuplicate}},
+		Errors: []error{validation.Error{Code: rule.NameSliceUnique, Message: eDuplicate}},
 	},
 })
@@ -24,9 +24,59 @@ func wrap(r func(interface{}) error) validation.Rule {
 	}
 }
 
+func bind(rules []validation.Rule, ctx interface{}) []func(interface{}) error {
+	fns := make([]func(interface{}) error, len(rules))
+	for i, r := range rules {
+		fns[i] = r(ctx)
+	}
+	return fns
+}
+
+// apply applies validators of the rules to v and appends their errors to
+// errs. It returns an error only if a validator fails fatally. Go panics of
+// the validators are recovered.
+func apply(rules []validation.Rule, fns []func(interface{}) error, v interface{}, errs []error, bail bool) (res []error, err error) {
+	i := 0
+	defer func() {
+		if r := recover(); r != nil {
+			res, err = nil, validation.Locate(validation.Recovered(r), rules[i], v)
+		}
+	}()
+
+	for ; i < len(fns); i++ {
+		if e := fns[i](v); e != nil {
+			if validation.Fatal(e) {
+				return nil, validation.Locate(e, rules[i], v)
+			} else if es, ok := e.(validation.Errors); ok {
+				errs = append(errs, []error(es)...)
+			} else {
+				errs = append(errs, e)
+			}
+			if bail && validation.Failed(e) {
+				break
+			}
+		}
+	}
+	return errs, nil
+}
+
+// collect collects non nil errors into validation.Errors.
+func collect(res []error) error {
+	errs := []error{}
+	for _, e := range res {
+		if e != nil {
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) > 0 {
+		return validation.Errors(errs)
+	}
+	return nil
+}
+
 // NotNil creates validator to check whether a value is nil.
 func NotNil(msg string) validation.Rule {
-	return wrap(func(v interface{}) error {
+	return describe(wrap(func(v interface{}) error {
 		t := reflect.TypeOf(v)
 		if t.Kind() != reflect.Ptr {
 			return unexpectedType(v)
@@ -41,14 +91,14 @@ func NotNil(msg string) validation.Rule {
 			reflect.Chan:
 
 			if reflect.ValueOf(v).Elem().IsNil() {
-				return validation.Error{Message: msg}
+				return validation.Error{Code: NameNotNil, Message: msg}
 			}
 		default:
 			return unexpectedType(v)
 		}
 
 		return nil
-	})
+	}), NameNotNil, msg, nil)
 }
 
 // In creates a validator to chech wheter an item belongs to the set provided.
@@ -58,7 +108,7 @@ func In(values []interface{}, msg string) validation.Rule {
 		set[v] = true
 	}
 
-	return wrap(func(v interface{}) error {
+	return describe(wrap(derefRule(func(v interface{}) error {
 		vl := reflect.ValueOf(v)
 		if vl.Type().Kind() != reflect.Ptr {
 			return unexpectedType(v)
@@ -67,11 +117,11 @@ func In(values []interface{}, msg string) validation.Rule {
 
 		k := vl.Interface()
 		if !set[k] {
-			return validation.Error{Message: msg, Params: validation.Params{
+			return validation.Error{Code: NameIn, Message: msg, Params: validation.Params{
 				ParamInUnsupported: k,
 				ParamInSupported:   values,
 			}}
 		}
 		return nil
-	})
+	})), NameIn, msg, validation.Params{ParamInSupported: values})
 }
@@ -22,7 +22,7 @@ func TestNotNil(t *testing.T) {
 	})
 	t.Run("ErrorIfNil", func(t *testing.T) {
 		var n interface{} = nil
-		require.Equal(t, validation.Error{Message: msg}, fun(&n))
+		require.Equal(t, validation.Error{Code: rule.NameNotNil, Message: msg}, fun(&n))
 
 	})
 	t.Run("OkIfNotNil", func(t *testing.T) {
@@ -41,7 +41,7 @@ func TestIn(t *testing.T) {
 	})
 	t.Run("ErrorIfNotIn", func(t *testing.T) {
 		val := "d4"
-		exp := validation.Error{Message: msg, Params: validation.Params{
+		exp := validation.Error{Code: rule.NameIn, Message: msg, Params: validation.Params{
 			rule.ParamInUnsupported: val,
 			rule.ParamInSupported:   set,
 		}}
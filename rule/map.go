@@ -0,0 +1,227 @@
+package rule
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+var (
+	ParamMapMinLen = "minLen"
+	ParamMapMaxLen = "maxLen"
+	ParamMapKeys   = "keys"
+)
+
+func mapRule(fn validation.Rule) validation.Rule {
+	return func(ctx interface{}) func(interface{}) error {
+		f := fn(ctx)
+		return derefRule(func(v interface{}) error {
+			t := reflect.TypeOf(v)
+			if t == nil || t.Kind() != reflect.Ptr {
+				return unexpectedType(v)
+			}
+
+			switch t.Elem().Kind() {
+			case reflect.Map:
+				return f(v)
+			default:
+				return unexpectedType(v)
+			}
+		})
+	}
+}
+
+// sortedKeys returns map keys in a deterministic order, so errors of map
+// entries are reported in the same order on every validation.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		default:
+			return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+		}
+	})
+	return keys
+}
+
+// ptr returns a pointer to a copy of the value provided, map keys and values
+// are not addressable.
+func ptr(v reflect.Value) interface{} {
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p.Interface()
+}
+
+// MapLen creates validator to check whether map length is in the range
+// provided.
+func MapLen(min, max int, msg string) validation.Rule {
+	return describe(mapRule(wrap(func(v interface{}) error {
+		n := reflect.ValueOf(v).Elem().Len()
+		if n < min || n > max {
+			return validation.Error{
+				Code:    NameMapLen,
+				Message: msg,
+				Params: validation.Params{
+					ParamMapMinLen: min,
+					ParamMapMaxLen: max,
+				},
+			}
+		}
+		return nil
+	})), NameMapLen, msg, validation.Params{
+		ParamMapMinLen: min,
+		ParamMapMaxLen: max,
+	})
+}
+
+// MapMinLen creates validator to check whether map length is not less than
+// the value provided.
+func MapMinLen(min int, msg string) validation.Rule {
+	return describe(mapRule(wrap(func(v interface{}) error {
+		n := reflect.ValueOf(v).Elem().Len()
+		if n < min {
+			return validation.Error{
+				Code:    NameMapMinLen,
+				Message: msg,
+				Params: validation.Params{
+					ParamMapMinLen: min,
+				},
+			}
+		}
+		return nil
+	})), NameMapMinLen, msg, validation.Params{ParamMapMinLen: min})
+}
+
+// MapMaxLen creates validator to check whether map length is not great than
+// the value provided.
+func MapMaxLen(max int, msg string) validation.Rule {
+	return describe(mapRule(wrap(func(v interface{}) error {
+		n := reflect.ValueOf(v).Elem().Len()
+		if n > max {
+			return validation.Error{
+				Code:    NameMapMaxLen,
+				Message: msg,
+				Params: validation.Params{
+					ParamMapMaxLen: max,
+				},
+			}
+		}
+		return nil
+	})), NameMapMaxLen, msg, validation.Params{ParamMapMaxLen: max})
+}
+
+// MapEach creates validator to check whether all keys of a map meet the keys
+// rules and all values meet the values rules. Errors of an entry are reported
+// as a MapError with the entry key.
+func MapEach(keys, values []validation.Rule) validation.Rule {
+	r := mapRule(func(ctx interface{}) func(interface{}) error {
+		mode := validation.ModeOf(ctx)
+		kfns := bind(keys, ctx)
+		vfns := bind(values, ctx)
+
+		return func(v interface{}) error {
+			m := reflect.ValueOf(v).Elem()
+			ks := sortedKeys(m)
+
+			res, err := validation.Each(ctx, len(ks), mode.BailField(), func(i int) error {
+				k := ks[i]
+				me, err := apply(keys, kfns, ptr(k), nil, mode.BailRule())
+				if err != nil {
+					return validation.Locate(err, nil, nil, validation.AtKey(k.Interface()))
+				}
+				me, err = apply(values, vfns, ptr(m.MapIndex(k)), me, mode.BailRule())
+				if err != nil {
+					return validation.Locate(err, nil, nil, validation.AtKey(k.Interface()))
+				}
+				if len(me) > 0 {
+					return validation.MapError{Key: k.Interface(), Errors: me}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			return collect(res)
+		}
+	})
+
+	return validation.Described(r, func() validation.Descriptor {
+		return validation.Descriptor{
+			Name:  NameMapEach,
+			Keys:  validation.DescribeAll(keys),
+			Items: validation.DescribeAll(values),
+		}
+	})
+}
+
+// MapKeys creates validator to check whether all keys of a map meet the rules
+// provided.
+func MapKeys(rules []validation.Rule) validation.Rule {
+	return MapEach(rules, nil)
+}
+
+// MapValues creates validator to check whether all values of a map meet the
+// rules provided.
+func MapValues(rules []validation.Rule) validation.Rule {
+	return MapEach(nil, rules)
+}
+
+// MapRequiredKeys creates validator to check whether a map contains all the
+// keys provided.
+func MapRequiredKeys(keys []interface{}, msg string) validation.Rule {
+	return describe(mapKeysRule(keys, msg, false), NameMapRequiredKeys, msg, validation.Params{
+		ParamMapKeys: keys,
+	})
+}
+
+// MapForbiddenKeys creates validator to check whether a map does not contain
+// any of the keys provided.
+func MapForbiddenKeys(keys []interface{}, msg string) validation.Rule {
+	return describe(mapKeysRule(keys, msg, true), NameMapForbiddenKeys, msg, validation.Params{
+		ParamMapKeys: keys,
+	})
+}
+
+func mapKeysRule(keys []interface{}, msg string, forbidden bool) validation.Rule {
+	code := NameMapRequiredKeys
+	if forbidden {
+		code = NameMapForbiddenKeys
+	}
+
+	return mapRule(wrap(func(v interface{}) error {
+		errs := []error{}
+
+		m := reflect.ValueOf(v).Elem()
+		for _, k := range keys {
+			kv := reflect.ValueOf(k)
+			if !kv.Type().AssignableTo(m.Type().Key()) {
+				return unexpectedType(k)
+			}
+
+			if m.MapIndex(kv).IsValid() == forbidden {
+				errs = append(errs, validation.MapError{
+					Key:    k,
+					Errors: []error{validation.Error{Code: code, Message: msg}},
+				})
+			}
+		}
+
+		if len(errs) > 0 {
+			return validation.Errors(errs)
+		}
+
+		return nil
+	}))
+}
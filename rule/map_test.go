@@ -0,0 +1,157 @@
+package rule_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+func TestMapLen(t *testing.T) {
+	msg := "ErrLen"
+	fun := rule.MapLen(1, 2, msg)(nil)
+	exp := validation.Error{
+		Code:    rule.NameMapLen,
+		Message: msg,
+		Params: validation.Params{
+			rule.ParamMapMinLen: 1,
+			rule.ParamMapMaxLen: 2,
+		},
+	}
+
+	t.Run("PanicIfNotPtr", func(t *testing.T) {
+		assertPanic(t, fun(map[string]int{}))
+	})
+	t.Run("PanicIfInvalidType", func(t *testing.T) {
+		v := []int{}
+		assertPanic(t, fun(&v))
+	})
+	t.Run("ErrorIfMin", func(t *testing.T) {
+		v := map[string]int{}
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("ErrorIfMax", func(t *testing.T) {
+		v := map[string]int{"a": 1, "b": 2, "c": 3}
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("OkIfInRange", func(t *testing.T) {
+		v := map[string]int{"a": 1}
+		require.Nil(t, fun(&v))
+	})
+}
+
+func TestMapMinLen(t *testing.T) {
+	fun := rule.MapMinLen(1, "ErrMinLen")(nil)
+
+	t.Run("ErrorIfMin", func(t *testing.T) {
+		v := map[string]int{}
+		require.Error(t, fun(&v))
+	})
+	t.Run("OkIfLenEq", func(t *testing.T) {
+		v := map[string]int{"a": 1}
+		require.Nil(t, fun(&v))
+	})
+}
+
+func TestMapMaxLen(t *testing.T) {
+	fun := rule.MapMaxLen(1, "ErrMaxLen")(nil)
+
+	t.Run("ErrorIfMax", func(t *testing.T) {
+		v := map[string]int{"a": 1, "b": 2}
+		require.Error(t, fun(&v))
+	})
+	t.Run("OkIfLenEq", func(t *testing.T) {
+		v := map[string]int{"a": 1}
+		require.Nil(t, fun(&v))
+	})
+}
+
+func TestMapEach(t *testing.T) {
+	keys := []validation.Rule{rule.StrMinLen(2, eMinLen)}
+	values := []validation.Rule{userRule}
+	fun := rule.MapEach(keys, values)(nil)
+
+	t.Run("PanicIfValidatorPanics", func(t *testing.T) {
+		v := map[string]User{"aa": {}}
+		assertPanic(t, rule.MapValues([]validation.Rule{rule.StrRequired(eBlank)})(nil)(&v))
+	})
+	t.Run("ErrorIfErrors", func(t *testing.T) {
+		v := map[string]User{
+			"bb": invalidUsers[2],
+			"a":  users[0],
+			"cc": users[1],
+		}
+		exp := validation.Errors([]error{
+			validation.MapError{
+				Key: "a",
+				Errors: []error{
+					validation.Error{Code: rule.NameStrMinLen, Message: eMinLen, Params: validation.Params{
+						rule.ParamStrMinLen: 2,
+					}},
+				},
+			},
+			validation.MapError{
+				Key:    "bb",
+				Errors: invalidUserErrors[2].(validation.SliceError).Errors,
+			},
+		})
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("OkIfNoErrors", func(t *testing.T) {
+		v := map[string]User{"aa": users[0], "bb": users[1]}
+		require.Nil(t, fun(&v))
+	})
+	t.Run("BailField", func(t *testing.T) {
+		ctx := validation.WithMode(context.Background(), validation.ModeBailField)
+		v := map[string]User{"a": users[0], "b": users[1]}
+		err := validation.Validate(ctx, rule.MapKeys(keys), &v)
+		require.Len(t, err, 1)
+	})
+}
+
+func TestMapRequiredKeys(t *testing.T) {
+	msg := "ErrRequired"
+	fun := rule.MapRequiredKeys([]interface{}{"env", "team"}, msg)(nil)
+
+	t.Run("PanicIfKeyTypeMismatch", func(t *testing.T) {
+		v := map[int]string{}
+		assertPanic(t, fun(&v))
+	})
+	t.Run("ErrorIfMissing", func(t *testing.T) {
+		v := map[string]string{"env": "prod"}
+		exp := validation.Errors([]error{
+			validation.MapError{
+				Key:    "team",
+				Errors: []error{validation.Error{Code: rule.NameMapRequiredKeys, Message: msg}},
+			},
+		})
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("OkIfPresent", func(t *testing.T) {
+		v := map[string]string{"env": "prod", "team": "core"}
+		require.Nil(t, fun(&v))
+	})
+}
+
+func TestMapForbiddenKeys(t *testing.T) {
+	msg := "ErrForbidden"
+	fun := rule.MapForbiddenKeys([]interface{}{"internal"}, msg)(nil)
+
+	t.Run("ErrorIfPresent", func(t *testing.T) {
+		v := map[string]string{"internal": "1"}
+		exp := validation.Errors([]error{
+			validation.MapError{
+				Key:    "internal",
+				Errors: []error{validation.Error{Code: rule.NameMapForbiddenKeys, Message: msg}},
+			},
+		})
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("OkIfAbsent", func(t *testing.T) {
+		v := map[string]string{"env": "prod"}
+		require.Nil(t, fun(&v))
+	})
+}
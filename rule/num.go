@@ -18,29 +18,29 @@ var (
 )
 
 func int64rule(fn func(int64) error) validation.Rule {
-	return wrap(func(v interface{}) error {
+	return wrap(derefRule(func(v interface{}) error {
 		switch x := v.(type) {
 		case *int:
 			return fn(int64(*x))
 		default:
 			return unexpectedType(v)
 		}
-	})
+	}))
 }
 
 func uint64rule(fn func(uint64) error) validation.Rule {
-	return wrap(func(v interface{}) error {
+	return wrap(derefRule(func(v interface{}) error {
 		switch x := v.(type) {
 		case *uint:
 			return fn(uint64(*x))
 		default:
 			return unexpectedType(v)
 		}
-	})
+	}))
 }
 
 func float64rule(fn func(v float64) error) validation.Rule {
-	return wrap(func(v interface{}) error {
+	return wrap(derefRule(func(v interface{}) error {
 		switch x := v.(type) {
 		case *float32:
 			return fn(float64(*x))
@@ -49,21 +49,22 @@ func float64rule(fn func(v float64) error) validation.Rule {
 		default:
 			return unexpectedType(v)
 		}
-	})
+	}))
 }
 
 func timerule(fn func(time.Time) error) validation.Rule {
-	return wrap(func(v interface{}) error {
+	return wrap(derefRule(func(v interface{}) error {
 		t, ok := v.(*time.Time)
 		if !ok {
 			return unexpectedType(v)
 		}
 		return fn(*t)
-	})
+	}))
 }
 
 func errorMin(min interface{}, msg string) validation.Error {
 	return validation.Error{
+		Code:    NameNumMin,
 		Message: msg,
 		Params: validation.Params{
 			ParamNumMin: min,
@@ -73,6 +74,7 @@ func errorMin(min interface{}, msg string) validation.Error {
 
 func errorMax(max interface{}, msg string) validation.Error {
 	return validation.Error{
+		Code:    NameNumMax,
 		Message: msg,
 		Params: validation.Params{
 			ParamNumMax: max,
@@ -82,6 +84,7 @@ func errorMax(max interface{}, msg string) validation.Error {
 
 func errorBetween(a, b interface{}, msg string) validation.Error {
 	return validation.Error{
+		Code:    NameNumBetween,
 		Message: msg,
 		Params: validation.Params{
 			ParamNumMin: a,
@@ -93,6 +96,12 @@ func errorBetween(a, b interface{}, msg string) validation.Error {
 // Min creates validator to check whether a number is not less than the
 // value provided.
 func Min(min interface{}, msg string) validation.Rule {
+	return describe(minRule(min, msg), NameNumMin, msg, validation.Params{
+		ParamNumMin: min,
+	})
+}
+
+func minRule(min interface{}, msg string) validation.Rule {
 	switch x := min.(type) {
 	case int:
 		a := int64(x)
@@ -142,6 +151,12 @@ func Min(min interface{}, msg string) validation.Rule {
 // Max creates validator to check whether a number is not great than the
 // value provided.
 func Max(max interface{}, msg string) validation.Rule {
+	return describe(maxRule(max, msg), NameNumMax, msg, validation.Params{
+		ParamNumMax: max,
+	})
+}
+
+func maxRule(max interface{}, msg string) validation.Rule {
 	switch x := max.(type) {
 	case int:
 		a := int64(x)
@@ -190,6 +205,13 @@ func Max(max interface{}, msg string) validation.Rule {
 
 // Between creates validator to check whether a number is the range provided.
 func Between(a, b interface{}, msg string) validation.Rule {
+	return describe(betweenRule(a, b, msg), NameNumBetween, msg, validation.Params{
+		ParamNumMin: a,
+		ParamNumMax: b,
+	})
+}
+
+func betweenRule(a, b interface{}, msg string) validation.Rule {
 	ta := reflect.TypeOf(a)
 	tb := reflect.TypeOf(b)
 
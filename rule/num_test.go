@@ -16,6 +16,7 @@ func TestMin(t *testing.T) {
 		min := 10
 		fun := rule.Min(min, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumMin,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMin: min,
@@ -47,6 +48,7 @@ func TestMin(t *testing.T) {
 		min := uint(10)
 		fun := rule.Min(min, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumMin,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMin: min,
@@ -78,6 +80,7 @@ func TestMin(t *testing.T) {
 		min := 10.0
 		fun := rule.Min(min, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumMin,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMin: min,
@@ -109,6 +112,7 @@ func TestMin(t *testing.T) {
 		min := time.Date(2018, 2, 9, 0, 0, 0, 0, time.Local)
 		fun := rule.Min(min, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumMin,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMin: min,
@@ -143,6 +147,7 @@ func TestMax(t *testing.T) {
 		max := 10
 		fun := rule.Max(max, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumMax,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMax: max,
@@ -174,6 +179,7 @@ func TestMax(t *testing.T) {
 		max := uint(10)
 		fun := rule.Max(max, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumMax,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMax: max,
@@ -205,6 +211,7 @@ func TestMax(t *testing.T) {
 		max := 10.0
 		fun := rule.Max(max, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumMax,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMax: max,
@@ -236,6 +243,7 @@ func TestMax(t *testing.T) {
 		max := time.Date(2018, 2, 5, 0, 0, 0, 0, time.Local)
 		fun := rule.Max(max, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumMax,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMax: max,
@@ -271,6 +279,7 @@ func TestBetween(t *testing.T) {
 		b := 20
 		fun := rule.Between(a, b, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumBetween,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMin: a,
@@ -312,6 +321,7 @@ func TestBetween(t *testing.T) {
 		b := uint(20)
 		fun := rule.Between(a, b, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumBetween,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMin: a,
@@ -353,6 +363,7 @@ func TestBetween(t *testing.T) {
 		b := 20.0
 		fun := rule.Between(a, b, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumBetween,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMin: a,
@@ -394,6 +405,7 @@ func TestBetween(t *testing.T) {
 		b := time.Date(2018, 1, 20, 0, 0, 0, 0, time.Local)
 		fun := rule.Between(a, b, msg)(nil)
 		exp := validation.Error{
+			Code:    rule.NameNumBetween,
 			Message: msg,
 			Params: validation.Params{
 				rule.ParamNumMin: a,
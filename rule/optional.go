@@ -0,0 +1,112 @@
+package rule
+
+import (
+	"reflect"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+// deref dereferences pointers to pointers down to a single pointer, so rules
+// expecting *T accept **T as well. A nil pointer at any level is replaced by
+// a pointer to the zero T, so the rules still check the type and validate
+// nil as the zero value, e.g. StrRequired fails on a nil **string. Only
+// Optional skips nil values. Values other than pointers are returned as is.
+func deref(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return v
+	}
+
+	for rv.Type().Elem().Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.IsNil() {
+		t := rv.Type().Elem()
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		return reflect.New(t).Interface()
+	}
+
+	return rv.Interface()
+}
+
+func derefRule(fn func(interface{}) error) func(interface{}) error {
+	return func(v interface{}) error {
+		return fn(deref(v))
+	}
+}
+
+// isNil reports whether the value pointed by v is nil. Pointers to pointers
+// are nil if any of the pointers is nil.
+func isNil(v interface{}) (bool, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return false, unexpectedType(v)
+	}
+
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return true, nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface, reflect.Slice, reflect.Func, reflect.Map, reflect.Chan:
+		return rv.IsNil(), nil
+	default:
+		return false, nil
+	}
+}
+
+// Optional creates validator which applies the rules provided only if the
+// value is not nil. A pointer to a pointer is dereferenced before passing it
+// to the rules, i.e. the rules get *T for **T.
+func Optional(rules ...validation.Rule) validation.Rule {
+	return validation.Described(func(ctx interface{}) func(interface{}) error {
+		fn := validation.Rules(rules)(ctx)
+		return func(v interface{}) error {
+			null, err := isNil(v)
+			if err != nil {
+				return err
+			}
+			if null {
+				return nil
+			}
+			return fn(deref(v))
+		}
+	}, func() validation.Descriptor {
+		return validation.Descriptor{
+			Name:  NameOptional,
+			Rules: validation.DescribeAll(rules),
+		}
+	})
+}
+
+// Required creates validator to check whether a value is set. Values of
+// nillable kinds are checked for nil, pointers to pointers are nil if any of
+// the pointers is nil. Values of other kinds are checked for zero value.
+func Required(msg string) validation.Rule {
+	return describe(wrap(func(v interface{}) error {
+		null, err := isNil(v)
+		if err != nil {
+			return err
+		}
+		if null {
+			return validation.Error{Code: NameRequired, Message: msg}
+		}
+
+		switch reflect.TypeOf(v).Elem().Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Func, reflect.Map, reflect.Chan:
+			return nil
+		}
+
+		if reflect.ValueOf(v).Elem().IsZero() {
+			return validation.Error{Code: NameRequired, Message: msg}
+		}
+
+		return nil
+	}), NameRequired, msg, nil)
+}
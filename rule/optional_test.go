@@ -0,0 +1,155 @@
+package rule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+func TestOptional(t *testing.T) {
+	fun := rule.Optional(rule.StrEmail(eEmail))(nil)
+
+	t.Run("PanicIfNotPtr", func(t *testing.T) {
+		assertPanic(t, fun("user"))
+	})
+	t.Run("OkIfNil", func(t *testing.T) {
+		var v *string
+		require.Nil(t, fun(&v))
+	})
+	t.Run("ErrorIfSetAndInvalid", func(t *testing.T) {
+		s := "user"
+		v := &s
+		exp := validation.Errors([]error{validation.Error{Code: rule.NameStrEmail, Message: eEmail}})
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("OkIfSetAndValid", func(t *testing.T) {
+		s := "user@mail.com"
+		v := &s
+		require.Nil(t, fun(&v))
+	})
+	t.Run("OkIfNilSlice", func(t *testing.T) {
+		var v []int
+		require.Nil(t, rule.Optional(rule.SliceMinLen(1, eMinLen))(nil)(&v))
+	})
+	t.Run("DerefForCustomRules", func(t *testing.T) {
+		n := 10
+		v := &n
+		fun := rule.Optional(validation.Func(func(v interface{}) error {
+			require.Equal(t, &n, v)
+			return nil
+		}))(nil)
+		require.Nil(t, fun(&v))
+	})
+}
+
+func TestRequired(t *testing.T) {
+	msg := "ErrRequired"
+	fun := rule.Required(msg)(nil)
+	exp := validation.Error{Code: rule.NameRequired, Message: msg}
+
+	t.Run("PanicIfNotPtr", func(t *testing.T) {
+		assertPanic(t, fun(10))
+	})
+	t.Run("ErrorIfNilPtr", func(t *testing.T) {
+		var v *string
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("ErrorIfNilPtrToPtr", func(t *testing.T) {
+		var s *string
+		v := &s
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("ErrorIfNilMap", func(t *testing.T) {
+		var v map[string]int
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("ErrorIfZero", func(t *testing.T) {
+		v := 0
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("OkIfEmptySlice", func(t *testing.T) {
+		v := []int{}
+		require.Nil(t, fun(&v))
+	})
+	t.Run("OkIfSet", func(t *testing.T) {
+		s := ""
+		v := &s
+		require.Nil(t, fun(&v))
+	})
+}
+
+func TestDeref(t *testing.T) {
+	t.Run("Str", func(t *testing.T) {
+		fun := rule.StrEmail(eEmail)(nil)
+		s := "user"
+		v := &s
+		require.Equal(t, validation.Error{Code: rule.NameStrEmail, Message: eEmail}, fun(&v))
+	})
+	t.Run("Num", func(t *testing.T) {
+		fun := rule.Min(10, "ErrMin")(nil)
+		var v *int
+		require.Error(t, fun(&v))
+
+		n := 1
+		v = &n
+		require.Error(t, fun(&v))
+
+		n = 10
+		require.Nil(t, fun(&v))
+	})
+	t.Run("Time", func(t *testing.T) {
+		now := time.Now()
+		fun := rule.Min(now, "ErrMin")(nil)
+		var v *time.Time
+		require.Error(t, fun(&v))
+
+		past := now.Add(-time.Hour)
+		v = &past
+		require.Error(t, fun(&v))
+	})
+	t.Run("Slice", func(t *testing.T) {
+		fun := rule.SliceMinLen(1, eMinLen)(nil)
+		s := []int{}
+		v := &s
+		require.Error(t, fun(&v))
+	})
+	t.Run("In", func(t *testing.T) {
+		fun := rule.In([]interface{}{"a"}, "ErrIn")(nil)
+		s := "b"
+		v := &s
+		require.Error(t, fun(&v))
+	})
+}
+
+func TestDerefNil(t *testing.T) {
+	t.Run("StrRequired", func(t *testing.T) {
+		var v *string
+		require.Equal(t, validation.Error{Code: rule.NameStrRequired, Message: eBlank},
+			rule.StrRequired(eBlank)(nil)(&v))
+	})
+	t.Run("StrMinLen", func(t *testing.T) {
+		var v *string
+		require.Error(t, rule.StrMinLen(1, eMinLen)(nil)(&v))
+	})
+	t.Run("SliceMinLen", func(t *testing.T) {
+		var v *[]int
+		require.Error(t, rule.SliceMinLen(1, eMinLen)(nil)(&v))
+	})
+	t.Run("MapRequiredKeys", func(t *testing.T) {
+		var v *map[string]int
+		require.Error(t, rule.MapRequiredKeys([]interface{}{"a"}, "ErrKeys")(nil)(v))
+		require.Error(t, rule.MapRequiredKeys([]interface{}{"a"}, "ErrKeys")(nil)(&v))
+	})
+	t.Run("TypeCheckedFirst", func(t *testing.T) {
+		var v *int
+		assertPanic(t, rule.StrRequired(eBlank)(nil)(&v))
+	})
+	t.Run("SkippedByOptional", func(t *testing.T) {
+		var v *string
+		require.Nil(t, rule.Optional(rule.StrRequired(eBlank))(nil)(&v))
+	})
+}
@@ -21,29 +21,31 @@ type SliceIter func(interface{}, int) interface{}
 
 func sliceRule(fn validation.Rule) validation.Rule {
 	return func(ctx interface{}) func(interface{}) error {
-		return func(v interface{}) error {
+		f := fn(ctx)
+		return derefRule(func(v interface{}) error {
 			t := reflect.TypeOf(v)
-			if t.Kind() != reflect.Ptr {
+			if t == nil || t.Kind() != reflect.Ptr {
 				return unexpectedType(v)
 			}
 
 			switch t.Elem().Kind() {
 			case reflect.Slice:
-				return fn(ctx)(v)
+				return f(v)
 			default:
 				return unexpectedType(v)
 			}
-		}
+		})
 	}
 }
 
 // SliceLen creates validator to check whether slice length is in the range
 // provided.
 func SliceLen(min, max int, msg string) validation.Rule {
-	return sliceRule(wrap(func(v interface{}) error {
+	return describe(sliceRule(wrap(func(v interface{}) error {
 		n := reflect.ValueOf(v).Elem().Len()
 		if n < min || n > max {
 			return validation.Error{
+				Code:    NameSliceLen,
 				Message: msg,
 				Params: validation.Params{
 					ParamSliceMinLen: min,
@@ -52,16 +54,20 @@ func SliceLen(min, max int, msg string) validation.Rule {
 			}
 		}
 		return nil
-	}))
+	})), NameSliceLen, msg, validation.Params{
+		ParamSliceMinLen: min,
+		ParamSliceMaxLen: max,
+	})
 }
 
 // SliceMinLen creates validator to check whether slice length is not less than
 // the value provided.
 func SliceMinLen(min int, msg string) validation.Rule {
-	return sliceRule(wrap(func(v interface{}) error {
+	return describe(sliceRule(wrap(func(v interface{}) error {
 		n := reflect.ValueOf(v).Elem().Len()
 		if n < min {
 			return validation.Error{
+				Code:    NameSliceMinLen,
 				Message: msg,
 				Params: validation.Params{
 					ParamSliceMinLen: min,
@@ -69,16 +75,17 @@ func SliceMinLen(min int, msg string) validation.Rule {
 			}
 		}
 		return nil
-	}))
+	})), NameSliceMinLen, msg, validation.Params{ParamSliceMinLen: min})
 }
 
 // SliceMaxLen creates validator to check whether slice length is not less than
 // the value provided.
 func SliceMaxLen(max int, msg string) validation.Rule {
-	return sliceRule(wrap(func(v interface{}) error {
+	return describe(sliceRule(wrap(func(v interface{}) error {
 		n := reflect.ValueOf(v).Elem().Len()
 		if n > max {
 			return validation.Error{
+				Code:    NameSliceMaxLen,
 				Message: msg,
 				Params: validation.Params{
 					ParamSliceMaxLen: max,
@@ -86,46 +93,40 @@ func SliceMaxLen(max int, msg string) validation.Rule {
 			}
 		}
 		return nil
-	}))
+	})), NameSliceMaxLen, msg, validation.Params{ParamSliceMaxLen: max})
 }
 
 // SliceEach creates validator to check whether all items of a slice meet the
 // rules provided.
 func SliceEach(iter SliceIter, rules []validation.Rule) validation.Rule {
-	return sliceRule(func(ctx interface{}) func(interface{}) error {
-		return func(v interface{}) error {
-			ses := []error{}
+	r := sliceRule(func(ctx interface{}) func(interface{}) error {
+		mode := validation.ModeOf(ctx)
+		fns := bind(rules, ctx)
 
+		return func(v interface{}) error {
 			n := reflect.ValueOf(v).Elem().Len()
-			for i := 0; i < n; i++ {
-				se := []error{}
-				k := iter(v, i)
-
-				for _, r := range rules {
-					if e := r(ctx)(k); e != nil {
-						if _, ok := e.(validation.Panic); ok {
-							return e
-						} else if es, ok := e.(validation.Errors); ok {
-							se = append(se, []error(es)...)
-						} else {
-							se = append(se, e)
-						}
-					}
+			res, err := validation.Each(ctx, n, mode.BailField(), func(i int) error {
+				se, err := apply(rules, fns, iter(v, i), nil, mode.BailRule())
+				if err != nil {
+					return validation.Locate(err, nil, nil, validation.AtIndex(i))
 				}
-
 				if len(se) > 0 {
-					ses = append(ses, validation.SliceError{
-						Index:  i,
-						Errors: se,
-					})
+					return validation.SliceError{Index: i, Errors: se}
 				}
+				return nil
+			})
+			if err != nil {
+				return err
 			}
 
-			if len(ses) > 0 {
-				return validation.Errors(ses)
-			}
+			return collect(res)
+		}
+	})
 
-			return nil
+	return validation.Described(r, func() validation.Descriptor {
+		return validation.Descriptor{
+			Name:  NameSliceEach,
+			Items: validation.DescribeAll(rules),
 		}
 	})
 }
@@ -133,32 +134,39 @@ func SliceEach(iter SliceIter, rules []validation.Rule) validation.Rule {
 // SliceUnique create validator to check wheter a slice contains only unique
 // items.
 func SliceUnique(iter SliceIter, msg string) validation.Rule {
-	return sliceRule(wrap(func(v interface{}) error {
-		errs := []error{}
-		set := map[interface{}]bool{}
+	return describe(sliceRule(func(ctx interface{}) func(interface{}) error {
+		bail := validation.ModeOf(ctx).BailField()
 
-		n := reflect.ValueOf(v).Elem().Len()
-		for i := 0; i < n; i++ {
-			p := reflect.ValueOf(iter(v, i))
-			if p.Type().Kind() != reflect.Ptr {
-				return validation.Panic{Err: fmt.Errorf(eNotPtr, i)}
-			}
+		return func(v interface{}) error {
+			errs := []error{}
+			set := map[interface{}]bool{}
+
+			n := reflect.ValueOf(v).Elem().Len()
+			for i := 0; i < n; i++ {
+				p := reflect.ValueOf(iter(v, i))
+				if p.Type().Kind() != reflect.Ptr {
+					return validation.Panic{Err: fmt.Errorf(eNotPtr, i)}
+				}
 
-			k := reflect.Indirect(p).Interface()
-			if set[k] {
-				errs = append(errs, validation.SliceError{
-					Index:  i,
-					Errors: []error{validation.Error{Message: msg}},
-				})
+				k := reflect.Indirect(p).Interface()
+				if set[k] {
+					errs = append(errs, validation.SliceError{
+						Index:  i,
+						Errors: []error{validation.Error{Code: NameSliceUnique, Message: msg}},
+					})
+					if bail {
+						break
+					}
+				}
+
+				set[k] = true
 			}
 
-			set[k] = true
-		}
+			if len(errs) > 0 {
+				return validation.Errors(errs)
+			}
 
-		if len(errs) > 0 {
-			return validation.Errors(errs)
+			return nil
 		}
-
-		return nil
-	}))
+	}), NameSliceUnique, msg, nil)
 }
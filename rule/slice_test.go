@@ -1,6 +1,7 @@
 package rule_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -16,6 +17,7 @@ func TestSliceLen(t *testing.T) {
 	msg := "ErrLen"
 	fun := rule.SliceLen(min, max, msg)(nil)
 	exp := validation.Error{
+		Code:    rule.NameSliceLen,
 		Message: msg,
 		Params: validation.Params{
 			rule.ParamSliceMinLen: min,
@@ -58,6 +60,7 @@ func TestSliceMinLen(t *testing.T) {
 	msg := "ErrMinLen"
 	fun := rule.SliceMinLen(min, msg)(nil)
 	exp := validation.Error{
+		Code:    rule.NameSliceMinLen,
 		Message: msg,
 		Params: validation.Params{
 			rule.ParamSliceMinLen: min,
@@ -90,6 +93,7 @@ func TestSliceMaxLen(t *testing.T) {
 	msg := "len must be not great than %d"
 	fun := rule.SliceMaxLen(max, msg)(nil)
 	exp := validation.Error{
+		Code:    rule.NameSliceMaxLen,
 		Message: msg,
 		Params: validation.Params{
 			rule.ParamSliceMaxLen: max,
@@ -169,3 +173,73 @@ func TestSliceUnique(t *testing.T) {
 		require.Nil(t, fun(&users))
 	})
 }
+
+func TestSliceEachCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	n := 0
+	fun := rule.SliceEach(userIter, []validation.Rule{
+		validation.Func(func(interface{}) error {
+			n++
+			cancel()
+			return nil
+		}),
+	})
+
+	err := validation.Validate(ctx, fun, &users)
+	require.Equal(t, validation.Canceled{Err: context.Canceled}, err)
+	require.Equal(t, 1, n)
+}
+
+func TestSliceEachMode(t *testing.T) {
+	fun := rule.SliceEach(userIter, []validation.Rule{userRule})
+
+	t.Run("BailField", func(t *testing.T) {
+		ctx := validation.WithMode(context.Background(), validation.ModeBailField)
+		exp := validation.Errors([]error{
+			validation.SliceError{
+				Index: 0,
+				Errors: []error{
+					validation.StructError{
+						Field: "Email",
+						Errors: []error{
+							validation.Error{Code: rule.NameStrRequired, Message: eBlank},
+							validation.Error{Code: rule.NameStrEmail, Message: eEmail},
+						},
+					},
+				},
+			},
+		})
+		require.Equal(t, exp, validation.Validate(ctx, fun, &invalidUsers))
+	})
+	t.Run("FailFast", func(t *testing.T) {
+		ctx := validation.WithMode(context.Background(), validation.ModeFailFast)
+		exp := validation.Errors([]error{
+			validation.SliceError{
+				Index: 0,
+				Errors: []error{
+					validation.StructError{
+						Field:  "Email",
+						Errors: []error{validation.Error{Code: rule.NameStrRequired, Message: eBlank}},
+					},
+				},
+			},
+		})
+		require.Equal(t, exp, validation.Validate(ctx, fun, &invalidUsers))
+	})
+}
+
+func TestSliceUniqueFailFast(t *testing.T) {
+	ctx := validation.WithMode(context.Background(), validation.ModeFailFast)
+	fun := rule.SliceUnique(userIter, eDuplicate)
+	exp := validation.Errors(duplicatedUserErrors[:1])
+	require.Equal(t, exp, validation.Validate(ctx, fun, &duplicatedUsers))
+}
+
+func TestSliceEachParallel(t *testing.T) {
+	ctx := validation.WithWorkers(context.Background(), 2)
+	fun := rule.SliceEach(userIter, []validation.Rule{userRule})
+
+	for i := 0; i < 10; i++ {
+		require.Equal(t, invalidUserErrors, validation.Validate(ctx, fun, &invalidUsers))
+	}
+}
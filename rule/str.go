@@ -13,111 +13,114 @@ var (
 	ParamStrMaxLen = "maxLen"
 	// ParamMaxLen is the name of string max length parameter.
 	ParamStrMinLen = "minLen"
+	// ParamStrPattern is the name of string pattern parameter.
+	ParamStrPattern = "pattern"
 )
 
 var (
 	// StrEmail creates validator to check whether a string is an email.
-	StrEmail = fromfn(govalidator.IsEmail)
+	StrEmail = fromfn(NameStrEmail, govalidator.IsEmail)
 	// StrIPv4 creates validator to check whether a string is an IPv4.
-	StrIPv4 = fromfn(govalidator.IsIPv4)
+	StrIPv4 = fromfn(NameStrIPv4, govalidator.IsIPv4)
 	// StrIPv6 creates validator to check whether a string is an IPv6.
-	StrIPv6 = fromfn(govalidator.IsIPv6)
+	StrIPv6 = fromfn(NameStrIPv6, govalidator.IsIPv6)
 	// StrIP creates validator to check whether a string is an IP.
-	StrIP = fromfn(govalidator.IsIP)
+	StrIP = fromfn(NameStrIP, govalidator.IsIP)
 	// StrIsURL creates validator to check whether a string is an URL.
-	StrIsURL = fromfn(govalidator.IsURL)
+	StrIsURL = fromfn(NameStrURL, govalidator.IsURL)
 	// StrIsUpperCase creates validator to check whether a string is in upper case.
-	StrIsUpperCase = fromfn(govalidator.IsUpperCase)
+	StrIsUpperCase = fromfn(NameStrUpperCase, govalidator.IsUpperCase)
 	// StrIsLowerCase creates validator to check whether a string is in lower case.
-	StrIsLowerCase = fromfn(govalidator.IsLowerCase)
+	StrIsLowerCase = fromfn(NameStrLowerCase, govalidator.IsLowerCase)
 	// StrIsJSON creates validator to check whether a string is a JSON.
-	StrIsJSON = fromfn(govalidator.IsJSON)
+	StrIsJSON = fromfn(NameStrJSON, govalidator.IsJSON)
 	// TODO(vbogretsov): import other string rules.
 )
 
 func strrule(fn func(*string) error) validation.Rule {
-	return wrap(func(v interface{}) error {
+	return wrap(derefRule(func(v interface{}) error {
 		s, ok := v.(*string)
 		if !ok {
 			return unexpectedType(v)
 		}
 		return fn(s)
-	})
+	}))
 }
 
-func fromfn(fn func(string) bool) func(string) validation.Rule {
+func fromfn(name string, fn func(string) bool) func(string) validation.Rule {
 	return func(msg string) validation.Rule {
-		return strrule(func(s *string) error {
+		return describe(strrule(func(s *string) error {
 			if !fn(*s) {
-				return validation.Error{Message: msg}
+				return validation.Error{Code: name, Message: msg}
 			}
 			return nil
-		})
+		}), name, msg, nil)
 	}
 }
 
 // StrLen creates validator to check whether length of a string is in the range
-// provided. The 'msg' parameter should be a format string with 2 slots for int.
+// provided. The 'msg' might refer the bounds as {minLen} and {maxLen}.
 func StrLen(min, max int, msg string) validation.Rule {
-	return strrule(func(s *string) error {
+	return describe(strrule(func(s *string) error {
 		n := len(*s)
 		if n < min || n > max {
-			return validation.Error{Message: msg, Params: validation.Params{
+			return validation.Error{Code: NameStrLen, Message: msg, Params: validation.Params{
 				ParamStrMinLen: min,
 				ParamStrMaxLen: max,
 			}}
 		}
 		return nil
+	}), NameStrLen, msg, validation.Params{
+		ParamStrMinLen: min,
+		ParamStrMaxLen: max,
 	})
 }
 
 // StrRequired creates validator to check whether a string is blank.
 func StrRequired(msg string) validation.Rule {
-	return strrule(func(s *string) error {
+	return describe(strrule(func(s *string) error {
 		if *s == "" {
-			return validation.Error{Message: msg}
+			return validation.Error{Code: NameStrRequired, Message: msg}
 		}
 		return nil
-	})
+	}), NameStrRequired, msg, nil)
 }
 
 // StrMinLen creates validator to check whether length of a string is not less
-// than the value provided. The 'msg' parameter should be a format string with
-// 1 slot for int.
+// than the value provided. The 'msg' might refer the bound as {minLen}.
 func StrMinLen(min int, msg string) validation.Rule {
-	return strrule(func(s *string) error {
+	return describe(strrule(func(s *string) error {
 		n := len(*s)
 		if n < min {
-			return validation.Error{Message: msg, Params: validation.Params{
+			return validation.Error{Code: NameStrMinLen, Message: msg, Params: validation.Params{
 				ParamStrMinLen: min,
 			}}
 		}
 		return nil
-	})
+	}), NameStrMinLen, msg, validation.Params{ParamStrMinLen: min})
 }
 
 // StrMaxLen creates validator to check whether length of a string is not great
-// than the value provided. The 'msg' parameter should be a format string with
-// 1 slot for int.
+// than the value provided. The 'msg' might refer the bound as {maxLen}.
 func StrMaxLen(max int, msg string) validation.Rule {
-	return strrule(func(s *string) error {
+	return describe(strrule(func(s *string) error {
 		n := len(*s)
 		if max < n {
-			return validation.Error{Message: msg, Params: validation.Params{
+			return validation.Error{Code: NameStrMaxLen, Message: msg, Params: validation.Params{
 				ParamStrMaxLen: max,
 			}}
 		}
 		return nil
-	})
+	}), NameStrMaxLen, msg, validation.Params{ParamStrMaxLen: max})
 }
 
 // StrMatch creates validator to check whether a string matches the regular
 // expression provided.
 func StrMatch(pattern *regexp.Regexp, msg string) validation.Rule {
-	return strrule(func(s *string) error {
+	return describe(strrule(func(s *string) error {
 		if !pattern.MatchString(*s) {
-			return validation.Error{Message: msg}
+			return validation.Error{Code: NameStrMatch, Message: msg}
 		}
 		return nil
-	})
+	}), NameStrMatch, msg, validation.Params{ParamStrPattern: pattern.String()})
 }
@@ -12,7 +12,7 @@ import (
 func TestStrRequired(t *testing.T) {
 	msg := "ErrBlank"
 	fun := rule.StrRequired(msg)(nil)
-	exp := validation.Error{Message: msg}
+	exp := validation.Error{Code: rule.NameStrRequired, Message: msg}
 
 	t.Run("PanicIfInvalidType", func(t *testing.T) {
 		assertPanic(t, fun(10))
@@ -33,6 +33,7 @@ func TestStrLen(t *testing.T) {
 	msg := "ErrLen"
 	fun := rule.StrLen(min, max, msg)(nil)
 	exp := validation.Error{
+		Code:    rule.NameStrLen,
 		Message: msg,
 		Params: validation.Params{
 			rule.ParamStrMinLen: min,
@@ -70,6 +71,7 @@ func TestStrMinLen(t *testing.T) {
 	msg := "ErrMinLen"
 	fun := rule.StrMinLen(min, msg)(nil)
 	exp := validation.Error{
+		Code:    rule.NameStrMinLen,
 		Message: msg,
 		Params: validation.Params{
 			rule.ParamStrMinLen: min,
@@ -91,6 +93,11 @@ func TestStrMinLen(t *testing.T) {
 		v := "12"
 		require.Nil(t, fun(&v))
 	})
+	t.Run("MessageInterpolated", func(t *testing.T) {
+		v := ""
+		err := rule.StrMinLen(min, "at least {minLen} characters")(nil)(&v)
+		require.EqualError(t, err, "at least 2 characters")
+	})
 }
 
 func TestStrMaxLen(t *testing.T) {
@@ -98,6 +105,7 @@ func TestStrMaxLen(t *testing.T) {
 	msg := "ErrMinLen"
 	fun := rule.StrMaxLen(max, msg)(nil)
 	exp := validation.Error{
+		Code:    rule.NameStrMaxLen,
 		Message: msg,
 		Params: validation.Params{
 			rule.ParamStrMaxLen: max,
@@ -124,7 +132,7 @@ func TestStrMaxLen(t *testing.T) {
 func TestStrMatch(t *testing.T) {
 	msg := "ErrMatch"
 	fun := rule.StrMatch(regexp.MustCompile(`\d+`), msg)(nil)
-	exp := validation.Error{Message: msg}
+	exp := validation.Error{Code: rule.NameStrMatch, Message: msg}
 
 	t.Run("PanicIfInvalidType", func(t *testing.T) {
 		assertPanic(t, fun(10))
@@ -138,3 +146,13 @@ func TestStrMatch(t *testing.T) {
 		require.Nil(t, fun(&v))
 	})
 }
+
+func TestStrErrorIs(t *testing.T) {
+	v := "a"
+	err := validation.Rules([]validation.Rule{
+		rule.StrMinLen(2, "ErrMinLen"),
+	})(nil)(&v)
+
+	require.ErrorIs(t, err, rule.ErrStrMinLen)
+	require.NotErrorIs(t, err, rule.ErrStrMaxLen)
+}
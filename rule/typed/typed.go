@@ -0,0 +1,194 @@
+// Package typed provides type-safe counterparts of the rule package
+// constructors.
+package typed
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+// Bound represents types supported by Min, Max and Between.
+type Bound interface {
+	int | uint | float32 | float64 | time.Time
+}
+
+// NotNil creates validator to check whether a value is nil.
+func NotNil[T any](msg string) validation.RuleOf[T] {
+	return validation.Typed[T](rule.NotNil(msg))
+}
+
+// Optional creates validator which applies the rules provided only if the
+// pointer validated is not nil.
+func Optional[T any](rules ...validation.RuleOf[T]) validation.RuleOf[*T] {
+	return validation.Typed[*T](rule.Optional(untyped(rules)...))
+}
+
+// Required creates validator to check whether a value is set.
+func Required[T any](msg string) validation.RuleOf[T] {
+	return validation.Typed[T](rule.Required(msg))
+}
+
+// In creates a validator to chech wheter an item belongs to the set provided.
+func In[T comparable](set []T, msg string) validation.RuleOf[T] {
+	return validation.Typed[T](rule.In(values(set), msg))
+}
+
+// Min creates validator to check whether a number is not less than the
+// value provided.
+func Min[T Bound](min T, msg string) validation.RuleOf[T] {
+	return validation.Typed[T](rule.Min(min, msg))
+}
+
+// Max creates validator to check whether a number is not great than the
+// value provided.
+func Max[T Bound](max T, msg string) validation.RuleOf[T] {
+	return validation.Typed[T](rule.Max(max, msg))
+}
+
+// Between creates validator to check whether a number is the range provided.
+func Between[T Bound](a, b T, msg string) validation.RuleOf[T] {
+	return validation.Typed[T](rule.Between(a, b, msg))
+}
+
+// SliceLen creates validator to check whether slice length is in the range
+// provided.
+func SliceLen[T any](min, max int, msg string) validation.RuleOf[[]T] {
+	return validation.Typed[[]T](rule.SliceLen(min, max, msg))
+}
+
+// SliceMinLen creates validator to check whether slice length is not less than
+// the value provided.
+func SliceMinLen[T any](min int, msg string) validation.RuleOf[[]T] {
+	return validation.Typed[[]T](rule.SliceMinLen(min, msg))
+}
+
+// SliceMaxLen creates validator to check whether slice length is not great
+// than the value provided.
+func SliceMaxLen[T any](max int, msg string) validation.RuleOf[[]T] {
+	return validation.Typed[[]T](rule.SliceMaxLen(max, msg))
+}
+
+// SliceEach creates validator to check whether all items of a slice meet the
+// rules provided.
+func SliceEach[T any](rules ...validation.RuleOf[T]) validation.RuleOf[[]T] {
+	return validation.Typed[[]T](rule.SliceEach(iter[T], untyped(rules)))
+}
+
+// SliceUnique create validator to check wheter a slice contains only unique
+// items.
+func SliceUnique[T comparable](msg string) validation.RuleOf[[]T] {
+	return validation.Typed[[]T](rule.SliceUnique(iter[T], msg))
+}
+
+// MapLen creates validator to check whether map length is in the range
+// provided.
+func MapLen[K comparable, V any](min, max int, msg string) validation.RuleOf[map[K]V] {
+	return validation.Typed[map[K]V](rule.MapLen(min, max, msg))
+}
+
+// MapMinLen creates validator to check whether map length is not less than
+// the value provided.
+func MapMinLen[K comparable, V any](min int, msg string) validation.RuleOf[map[K]V] {
+	return validation.Typed[map[K]V](rule.MapMinLen(min, msg))
+}
+
+// MapMaxLen creates validator to check whether map length is not great than
+// the value provided.
+func MapMaxLen[K comparable, V any](max int, msg string) validation.RuleOf[map[K]V] {
+	return validation.Typed[map[K]V](rule.MapMaxLen(max, msg))
+}
+
+// MapEach creates validator to check whether all keys of a map meet the keys
+// rules and all values meet the values rules.
+func MapEach[K comparable, V any](keys []validation.RuleOf[K], values []validation.RuleOf[V]) validation.RuleOf[map[K]V] {
+	return validation.Typed[map[K]V](rule.MapEach(untyped(keys), untyped(values)))
+}
+
+// MapRequiredKeys creates validator to check whether a map contains all the
+// keys provided.
+func MapRequiredKeys[K comparable, V any](keys []K, msg string) validation.RuleOf[map[K]V] {
+	return validation.Typed[map[K]V](rule.MapRequiredKeys(values(keys), msg))
+}
+
+// MapForbiddenKeys creates validator to check whether a map does not contain
+// any of the keys provided.
+func MapForbiddenKeys[K comparable, V any](keys []K, msg string) validation.RuleOf[map[K]V] {
+	return validation.Typed[map[K]V](rule.MapForbiddenKeys(values(keys), msg))
+}
+
+// StrLen creates validator to check whether length of a string is in the range
+// provided.
+func StrLen(min, max int, msg string) validation.RuleOf[string] {
+	return validation.Typed[string](rule.StrLen(min, max, msg))
+}
+
+// StrRequired creates validator to check whether a string is blank.
+func StrRequired(msg string) validation.RuleOf[string] {
+	return validation.Typed[string](rule.StrRequired(msg))
+}
+
+// StrMinLen creates validator to check whether length of a string is not less
+// than the value provided.
+func StrMinLen(min int, msg string) validation.RuleOf[string] {
+	return validation.Typed[string](rule.StrMinLen(min, msg))
+}
+
+// StrMaxLen creates validator to check whether length of a string is not great
+// than the value provided.
+func StrMaxLen(max int, msg string) validation.RuleOf[string] {
+	return validation.Typed[string](rule.StrMaxLen(max, msg))
+}
+
+// StrMatch creates validator to check whether a string matches the regular
+// expression provided.
+func StrMatch(pattern *regexp.Regexp, msg string) validation.RuleOf[string] {
+	return validation.Typed[string](rule.StrMatch(pattern, msg))
+}
+
+var (
+	// StrEmail creates validator to check whether a string is an email.
+	StrEmail = fromfn(rule.StrEmail)
+	// StrIPv4 creates validator to check whether a string is an IPv4.
+	StrIPv4 = fromfn(rule.StrIPv4)
+	// StrIPv6 creates validator to check whether a string is an IPv6.
+	StrIPv6 = fromfn(rule.StrIPv6)
+	// StrIP creates validator to check whether a string is an IP.
+	StrIP = fromfn(rule.StrIP)
+	// StrIsURL creates validator to check whether a string is an URL.
+	StrIsURL = fromfn(rule.StrIsURL)
+	// StrIsUpperCase creates validator to check whether a string is in upper case.
+	StrIsUpperCase = fromfn(rule.StrIsUpperCase)
+	// StrIsLowerCase creates validator to check whether a string is in lower case.
+	StrIsLowerCase = fromfn(rule.StrIsLowerCase)
+	// StrIsJSON creates validator to check whether a string is a JSON.
+	StrIsJSON = fromfn(rule.StrIsJSON)
+)
+
+func fromfn(fn func(string) validation.Rule) func(string) validation.RuleOf[string] {
+	return func(msg string) validation.RuleOf[string] {
+		return validation.Typed[string](fn(msg))
+	}
+}
+
+func iter[T any](v interface{}, i int) interface{} {
+	return &(*v.(*[]T))[i]
+}
+
+func untyped[T any](rules []validation.RuleOf[T]) []validation.Rule {
+	rs := make([]validation.Rule, len(rules))
+	for i, r := range rules {
+		rs[i] = r.Untyped()
+	}
+	return rs
+}
+
+func values[T any](items []T) []interface{} {
+	vs := make([]interface{}, len(items))
+	for i, v := range items {
+		vs[i] = v
+	}
+	return vs
+}
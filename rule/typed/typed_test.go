@@ -0,0 +1,164 @@
+package typed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+	"github.com/vbogretsov/go-validation/rule/typed"
+)
+
+const (
+	eBlank     = "ErrBlank"
+	eEmail     = "ErrEmail"
+	eMin       = "ErrMin"
+	eDuplicate = "ErrDuplicate"
+)
+
+type User struct {
+	Email string
+	Age   int
+}
+
+var userRule = validation.StructOf[User](``,
+	validation.FieldFor(func(u *User) *string { return &u.Email },
+		typed.StrRequired(eBlank),
+		typed.StrEmail(eEmail),
+	),
+	validation.FieldFor(func(u *User) *int { return &u.Age },
+		typed.Min(18, eMin),
+	),
+)
+
+func TestStruct(t *testing.T) {
+	t.Run("ErrorIfInvalid", func(t *testing.T) {
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field:  "Email",
+				Errors: []error{validation.Error{Code: rule.NameStrEmail, Message: eEmail}},
+			},
+			validation.StructError{
+				Field: "Age",
+				Errors: []error{validation.Error{
+					Code:    rule.NameNumMin,
+					Message: eMin,
+					Params:  validation.Params{rule.ParamNumMin: 18},
+				}},
+			},
+		})
+		require.Equal(t, exp, userRule(nil)(&User{Email: "user", Age: 17}))
+	})
+	t.Run("OkIfValid", func(t *testing.T) {
+		require.NoError(t, userRule(nil)(&User{Email: "user@mail.com", Age: 18}))
+	})
+}
+
+func TestSliceEach(t *testing.T) {
+	fun := typed.SliceEach(userRule)(nil)
+
+	t.Run("ErrorIfItemInvalid", func(t *testing.T) {
+		v := []User{{Email: "user@mail.com", Age: 20}, {Email: "", Age: 20}}
+		err := fun(&v).(validation.Errors)
+		require.Len(t, err, 1)
+		require.Equal(t, 1, err[0].(validation.SliceError).Index)
+	})
+	t.Run("OkIfItemsValid", func(t *testing.T) {
+		v := []User{{Email: "user@mail.com", Age: 20}}
+		require.NoError(t, fun(&v))
+	})
+}
+
+func TestSliceUnique(t *testing.T) {
+	fun := typed.SliceUnique[string](eDuplicate)(nil)
+
+	t.Run("ErrorIfDuplicated", func(t *testing.T) {
+		v := []string{"a", "b", "a"}
+		exp := validation.Errors([]error{
+			validation.SliceError{
+				Index:  2,
+				Errors: []error{validation.Error{Code: rule.NameSliceUnique, Message: eDuplicate}},
+			},
+		})
+		require.Equal(t, exp, fun(&v))
+	})
+	t.Run("OkIfUnique", func(t *testing.T) {
+		v := []string{"a", "b"}
+		require.NoError(t, fun(&v))
+	})
+}
+
+func TestBetween(t *testing.T) {
+	a := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := a.Add(time.Hour)
+	fun := typed.Between(a, b, "ErrBetween")(nil)
+
+	t.Run("ErrorIfOutOfRange", func(t *testing.T) {
+		v := b.Add(time.Second)
+		require.Error(t, fun(&v))
+	})
+	t.Run("OkIfInRange", func(t *testing.T) {
+		v := a.Add(time.Minute)
+		require.NoError(t, fun(&v))
+	})
+}
+
+func TestIn(t *testing.T) {
+	fun := typed.In([]string{"a", "b"}, "ErrIn")(nil)
+
+	t.Run("ErrorIfNotIn", func(t *testing.T) {
+		v := "c"
+		require.Error(t, fun(&v))
+	})
+	t.Run("OkIfIn", func(t *testing.T) {
+		v := "a"
+		require.NoError(t, fun(&v))
+	})
+}
+
+func TestMapEach(t *testing.T) {
+	fun := typed.MapEach(
+		[]validation.RuleOf[string]{typed.StrMinLen(2, "ErrMinLen")},
+		[]validation.RuleOf[User]{userRule},
+	)(nil)
+
+	t.Run("ErrorIfEntryInvalid", func(t *testing.T) {
+		v := map[string]User{"a": {Email: "user@mail.com", Age: 20}}
+		err := fun(&v).(validation.Errors)
+		require.Len(t, err, 1)
+		require.Equal(t, "a", err[0].(validation.MapError).Key)
+	})
+	t.Run("OkIfEntriesValid", func(t *testing.T) {
+		v := map[string]User{"aa": {Email: "user@mail.com", Age: 20}}
+		require.NoError(t, fun(&v))
+	})
+}
+
+func TestMapRequiredKeys(t *testing.T) {
+	fun := typed.MapRequiredKeys[string, int]([]string{"a"}, "ErrRequired")(nil)
+
+	t.Run("ErrorIfMissing", func(t *testing.T) {
+		v := map[string]int{"b": 1}
+		require.Error(t, fun(&v))
+	})
+	t.Run("OkIfPresent", func(t *testing.T) {
+		v := map[string]int{"a": 1}
+		require.NoError(t, fun(&v))
+	})
+}
+
+func TestOptional(t *testing.T) {
+	fun := typed.Optional(typed.StrEmail(eEmail))(nil)
+
+	t.Run("OkIfNil", func(t *testing.T) {
+		var v *string
+		require.NoError(t, fun(&v))
+	})
+	t.Run("ErrorIfInvalid", func(t *testing.T) {
+		s := "user"
+		v := &s
+		require.Error(t, fun(&v))
+	})
+}
@@ -0,0 +1,393 @@
+package validation
+
+import (
+	"reflect"
+	"sync"
+)
+
+type schemaField struct {
+	Field
+	path    []string
+	typ     reflect.Type
+	fixed   bool
+	self    bool
+	ignored bool
+}
+
+// Schema represents a precompiled struct schema. Field names are resolved
+// once at compile time, so validation does not need reflection.
+type Schema struct {
+	typ     reflect.Type
+	name    NameFunc
+	embed   Embedding
+	inherit bool
+	verify  bool
+	ftab    *fieldTable
+	fields  []schemaField
+}
+
+// Compile compiles a struct schema. The value v should be a pointer to the
+// struct validated, tag is the name of the struct tag holding field names.
+// The options might change the field naming, add the rules of embedded
+// structs and verify the schema.
+func Compile(v interface{}, tag string, fields []Field, opts ...Option) (*Schema, error) {
+	tp := reflect.TypeOf(v)
+	if tp == nil || tp.Kind() != reflect.Ptr {
+		return nil, errorArgs
+	}
+	if tp.Elem().Kind() != reflect.Struct {
+		return nil, errorArgs
+	}
+
+	s := newSchema(tp, tag, opts)
+	fields = s.inherited(fields)
+	if s.verify {
+		if err := s.check(fields); err != nil {
+			return nil, err
+		}
+	}
+	s.fields = make([]schemaField, len(fields))
+
+	sample := reflect.New(tp.Elem()).Interface()
+	for i, f := range fields {
+		sf, err := s.compileField(f, sample)
+		if err != nil {
+			return nil, err
+		}
+		s.fields[i] = sf
+	}
+	s.register()
+
+	return s, nil
+}
+
+func newSchema(tp reflect.Type, tag string, opts []Option) *Schema {
+	s := &Schema{typ: tp, name: naming(tag)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ftab = newFieldTable(tp.Elem(), s.name, s.embed)
+	return s
+}
+
+func (s *Schema) compileField(f Field, sample interface{}) (sf schemaField, err error) {
+	sf.Field = f
+
+	// Attr might fail on a zero value, e.g. if it dereferences a nil pointer,
+	// the field name is resolved on every validation in this case.
+	defer func() {
+		if recover() != nil {
+			sf.fixed = false
+			err = nil
+		}
+	}()
+
+	attr := f.Attr(sample)
+	if attr == sample {
+		sf.fixed = true
+		sf.self = true
+		return sf, nil
+	}
+
+	if reflect.ValueOf(attr).Kind() != reflect.Ptr {
+		return sf, Locate(errorAttr, nil, attr)
+	}
+
+	fn, fixed := s.ftab.find(sample, attr)
+	sf.path, sf.ignored, sf.fixed = fn.path, fn.ignored, fixed
+	if sf.fixed {
+		sf.typ = reflect.TypeOf(attr).Elem()
+	}
+
+	return sf, nil
+}
+
+func (s *Schema) path(f *schemaField, v, attr interface{}) []string {
+	if f.fixed || attr == v {
+		return f.path
+	}
+	path, _ := s.ftab.lookup(v, attr)
+	return path
+}
+
+// schemas maps struct types to the schemas having struct level rules, so the
+// rules resolve names of the struct fields whatever the validation context
+// is. If a type has several such schemas, the last compiled one is used.
+var schemas sync.Map
+
+// register makes the schema resolve the field names of its struct type if
+// the schema has struct level rules.
+func (s *Schema) register() {
+	for _, f := range s.fields {
+		if f.self || !f.fixed {
+			schemas.Store(s.typ, s)
+			return
+		}
+	}
+}
+
+// FieldPath gets the path of the field pointed by attr within the struct v.
+// The names follow the schema of the struct type having struct level rules,
+// i.e. rules of a Field which Attr returns the struct itself, the Go names of
+// the fields are used if there is no such schema. The ctx is not used, it is
+// kept so the rules can pass their context. It returns nil if the field is
+// not found.
+func FieldPath(ctx, v interface{}, attr Attr) []string {
+	tp := reflect.TypeOf(v)
+	if tp == nil || tp.Kind() != reflect.Ptr || tp.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	var ftab *fieldTable
+	if s, ok := schemas.Load(tp); ok {
+		ftab = s.(*Schema).ftab
+	} else {
+		ftab = newFieldTable(tp.Elem(), GoName, EmbedNested)
+	}
+
+	p := attr(v)
+	if reflect.ValueOf(p).Kind() != reflect.Ptr {
+		return nil
+	}
+
+	path, _ := ftab.lookup(v, p)
+	return path
+}
+
+// FieldError creates an error of the field pointed by attr within the struct
+// v. Struct level rules use it to report errors of a particular field.
+func FieldError(ctx, v interface{}, attr Attr, errs ...error) error {
+	path := FieldPath(ctx, v, attr)
+	if len(path) == 0 {
+		return Errors(errs)
+	}
+	return nest(path, errs)
+}
+
+// nest wraps errors of the field located by path into nested StructErrors.
+func nest(path []string, errs []error) StructError {
+	se := StructError{Field: path[len(path)-1], Errors: errs}
+	for i := len(path) - 2; i >= 0; i-- {
+		se = StructError{Field: path[i], Errors: []error{se}}
+	}
+	return se
+}
+
+// appendField appends a field error to errs. Errors of the same field and of
+// nested fields are grouped under a single StructError.
+func appendField(errs []error, fe StructError) []error {
+	if fe.Field == "" {
+		return append(errs, fe)
+	}
+
+	for i, e := range errs {
+		if se, ok := e.(StructError); ok && se.Field == fe.Field {
+			for _, c := range fe.Errors {
+				if ce, ok := c.(StructError); ok {
+					se.Errors = appendField(se.Errors, ce)
+				} else {
+					se.Errors = append(se.Errors, c)
+				}
+			}
+			errs[i] = se
+			return errs
+		}
+	}
+
+	return append(errs, fe)
+}
+
+// appendSelf appends errors of a field validating the whole struct. Errors
+// of other fields reported by the struct level rules are placed under their
+// fields.
+func appendSelf(errs []error, fe []error) []error {
+	var rest []error
+	for _, e := range fe {
+		if se, ok := e.(StructError); ok && se.Field != "" {
+			errs = appendField(errs, se)
+		} else {
+			rest = append(rest, e)
+		}
+	}
+	if len(rest) > 0 {
+		errs = append(errs, StructError{Field: "", Errors: rest})
+	}
+	return errs
+}
+
+// Rule binds the schema to a validation context. It is a Rule itself.
+func (s *Schema) Rule(ctx interface{}) func(interface{}) error {
+	return s.Bind(ctx).Validate
+}
+
+// Bind binds the schema rules to the validation context provided.
+func (s *Schema) Bind(ctx interface{}) *Plan {
+	p := &Plan{
+		schema: s,
+		ctx:    ctx,
+		mode:   ModeOf(ctx),
+		rules:  make([][]func(interface{}) error, len(s.fields)),
+	}
+	for i, f := range s.fields {
+		p.rules[i] = bind(f.Rules, withMode(ctx, f.Mode))
+	}
+	return p
+}
+
+// Plan represents a struct schema bound to a validation context. A Plan
+// does not allocate if the value validated is valid and might be reused
+// across validations.
+type Plan struct {
+	schema *Schema
+	ctx    interface{}
+	mode   Mode
+	rules  [][]func(interface{}) error
+}
+
+// Validate validates v against the plan.
+func (p *Plan) Validate(v interface{}) error {
+	if reflect.TypeOf(v) != p.schema.typ {
+		return Locate(errorArgs, nil, v)
+	}
+
+	if poolOf(p.ctx) != nil {
+		return p.validateParallel(v)
+	}
+
+	var errs []error
+	for i := range p.schema.fields {
+		if err := Interrupted(p.ctx); err != nil {
+			return err
+		}
+
+		attr, fe, err := p.field(i, v)
+		if err != nil {
+			return err
+		}
+
+		if len(fe) > 0 {
+			errs = p.append(errs, i, v, attr, fe)
+			if p.bail(i) && Failed(Errors(fe)) {
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return Errors(errs)
+	}
+
+	return nil
+}
+
+func (p *Plan) validateParallel(v interface{}) error {
+	attrs := make([]interface{}, len(p.schema.fields))
+
+	res, err := Each(p.ctx, len(p.schema.fields), p.mode.BailField(), func(i int) error {
+		attr, fe, err := p.field(i, v)
+		if err != nil {
+			return err
+		}
+		attrs[i] = attr
+		if len(fe) > 0 {
+			return Errors(fe)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i, fe := range res {
+		if fe != nil {
+			errs = p.append(errs, i, v, attrs[i], fe.(Errors))
+			if p.bail(i) && Failed(fe) {
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return Errors(errs)
+	}
+
+	return nil
+}
+
+// bail reports whether the struct validation stops if the field i fails.
+func (p *Plan) bail(i int) bool {
+	return p.schema.fields[i].Mode.Or(p.mode).BailField()
+}
+
+// field validates the field i of v. It returns the field pointer, the field
+// errors and a fatal error if any. Go panics of Attr and the rules are
+// recovered, panics are located at the field.
+func (p *Plan) field(i int, v interface{}) (attr interface{}, fe []error, err error) {
+	f := &p.schema.fields[i]
+	j := -1
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = Recovered(r)
+			if j >= 0 {
+				err = Locate(err, f.Rules[j], attr)
+			}
+		}
+		if _, ok := err.(Panic); ok {
+			attr, fe, err = nil, nil, p.locate(err, f, v, attr)
+		}
+	}()
+
+	attr = f.Attr(v)
+	if !f.fixed && reflect.ValueOf(attr).Kind() != reflect.Ptr {
+		return nil, nil, Locate(errorAttr, nil, attr)
+	}
+
+	bail := f.Mode.Or(p.mode).BailRule()
+
+	for j = range p.rules[i] {
+		if err := p.rules[i][j](attr); err != nil {
+			if Fatal(err) {
+				return attr, nil, Locate(err, f.Rules[j], attr)
+			}
+			if e, ok := err.(Errors); ok {
+				fe = append(fe, e...)
+			} else {
+				fe = append(fe, err)
+			}
+			if bail && Failed(err) {
+				break
+			}
+		}
+	}
+
+	return attr, fe, nil
+}
+
+// locate prepends the path of the field f to a Panic.
+func (p *Plan) locate(err error, f *schemaField, v, attr interface{}) error {
+	path := f.path
+	if !f.fixed && reflect.ValueOf(attr).Kind() == reflect.Ptr {
+		path, _ = p.schema.ftab.lookup(v, attr)
+	}
+	if len(path) == 0 {
+		return err
+	}
+	return Locate(err, nil, nil, fieldPath(path)...)
+}
+
+// append appends errors of the field i to errs.
+func (p *Plan) append(errs []error, i int, v, attr interface{}, fe []error) []error {
+	f := &p.schema.fields[i]
+	path := p.schema.path(f, v, attr)
+	switch {
+	case len(path) > 0:
+		return appendField(errs, nest(path, fe))
+	case path != nil || attr == v:
+		// The struct itself or a flattened embedded struct.
+		return appendSelf(errs, fe)
+	default:
+		return append(errs, StructError{Field: "", Errors: fe})
+	}
+}
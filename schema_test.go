@@ -0,0 +1,109 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+func TestCompile(t *testing.T) {
+	t.Run("ErrorIfNotPtr", func(t *testing.T) {
+		_, err := validation.Compile(Address{}, ``, []validation.Field{})
+		require.IsType(t, validation.Panic{}, err)
+	})
+	t.Run("ErrorIfNotStruct", func(t *testing.T) {
+		_, err := validation.Compile(&[]int{}, ``, []validation.Field{})
+		require.IsType(t, validation.Panic{}, err)
+	})
+	t.Run("ErrorIfAttrNotPtr", func(t *testing.T) {
+		_, err := validation.Compile(&Address{}, ``, []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return v.(*Address).Country
+				},
+			},
+		})
+		require.IsType(t, validation.Panic{}, err)
+	})
+	t.Run("OkIfAttrPanicsOnZeroValue", func(t *testing.T) {
+		type Node struct {
+			Next *Node
+			Name string
+		}
+		s, err := validation.Compile(&Node{}, ``, []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Node).Next.Name
+				},
+				Rules: []validation.Rule{validation.Func(stringRequired)},
+			},
+		})
+		require.NoError(t, err)
+		require.Error(t, s.Bind(nil).Validate(&Node{Next: &Node{}}))
+	})
+	t.Run("PathBehindNamedPtr", func(t *testing.T) {
+		type Order struct {
+			Address *Address `json:"address"`
+		}
+		r := validation.Struct(&Order{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Order).Address.City
+				},
+				Rules: []validation.Rule{validation.Func(stringRequired)},
+			},
+		})
+		require.Equal(t, validation.Errors{
+			validation.StructError{Field: "address", Errors: validation.Errors{
+				validation.StructError{Field: "city", Errors: validation.Errors{errors.New(eRequired)}},
+			}},
+		}, r(nil)(&Order{Address: &Address{}}))
+	})
+}
+
+func TestPlan(t *testing.T) {
+	s, err := validation.Compile(&Address{}, ``, []validation.Field{
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*Address).Country
+			},
+			Rules: []validation.Rule{
+				validation.Func(stringRequired),
+				validation.Func(startsUpperCase),
+			},
+		},
+		{
+			Attr: func(v interface{}) interface{} {
+				return &v.(*Address).ZipCode
+			},
+			Rules: []validation.Rule{
+				validation.Func(stringRequired),
+				validation.Func(zipCode),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	plan := s.Bind(nil)
+
+	t.Run("PanicIfInvalidType", func(t *testing.T) {
+		require.IsType(t, validation.Panic{}, plan.Validate(&User{}))
+	})
+	for k, v := range addressFixtures {
+		t.Run("ValidateAddress", func(t *testing.T) {
+			require.Equal(t, v, plan.Validate(&k))
+		})
+	}
+	t.Run("NoAllocsIfValid", func(t *testing.T) {
+		a := &Address{Country: "Russia", ZipCode: "123"}
+		n := testing.AllocsPerRun(100, func() {
+			if err := plan.Validate(a); err != nil {
+				t.Fatal(err)
+			}
+		})
+		require.Zero(t, n)
+	})
+}
@@ -0,0 +1,264 @@
+package tags
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+// DefaultCatalog contains the default messages of the built-in rules.
+var DefaultCatalog = Catalog{
+	"required": "cannot be blank",
+	"len":      "has invalid length",
+	"minLen":   "is too short",
+	"maxLen":   "is too long",
+	"min":      "is too small",
+	"max":      "is too large",
+	"between":  "is out of range",
+	"in":       "is not supported",
+	"match":    "has invalid format",
+	"unique":   "contains duplicates",
+	"email":    "is not a valid email",
+	"ipv4":     "is not a valid IPv4",
+	"ipv6":     "is not a valid IPv6",
+	"ip":       "is not a valid IP",
+	"url":      "is not a valid URL",
+	"upper":    "should be in upper case",
+	"lower":    "should be in lower case",
+	"json":     "is not a valid JSON",
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// orderedTypes are the types supported by the rules min, max and between.
+// The rules do not accept named types, e.g. type Level int.
+var orderedTypes = map[reflect.Type]bool{
+	reflect.TypeOf(int(0)):     true,
+	reflect.TypeOf(uint(0)):    true,
+	reflect.TypeOf(float32(0)): true,
+	reflect.TypeOf(float64(0)): true,
+	timeType:                   true,
+}
+
+var builtins = map[string]Factory{
+	"required": required,
+	"len":      length(rule.StrLen, rule.SliceLen, rule.MapLen),
+	"minLen":   bound(rule.StrMinLen, rule.SliceMinLen, rule.MapMinLen),
+	"maxLen":   bound(rule.StrMaxLen, rule.SliceMaxLen, rule.MapMaxLen),
+	"min":      number(rule.Min),
+	"max":      number(rule.Max),
+	"between":  between,
+	"in":       in,
+	"match":    match,
+	"unique":   unique,
+	"email":    str(rule.StrEmail),
+	"ipv4":     str(rule.StrIPv4),
+	"ipv6":     str(rule.StrIPv6),
+	"ip":       str(rule.StrIP),
+	"url":      str(rule.StrIsURL),
+	"upper":    str(rule.StrIsUpperCase),
+	"lower":    str(rule.StrIsLowerCase),
+	"json":     str(rule.StrIsJSON),
+}
+
+func nargs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("expected %d arguments but got %d", n, len(args))
+	}
+	return nil
+}
+
+func unsupported(t reflect.Type) error {
+	return fmt.Errorf("unsupported type %v", t)
+}
+
+func required(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+	if err := nargs(args, 0); err != nil {
+		return nil, err
+	}
+	if t.Kind() == reflect.String {
+		return rule.StrRequired(msg), nil
+	}
+	return rule.Required(msg), nil
+}
+
+func str(fn func(string) validation.Rule) Factory {
+	return func(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+		if err := nargs(args, 0); err != nil {
+			return nil, err
+		}
+		if t.Kind() != reflect.String {
+			return nil, unsupported(t)
+		}
+		return fn(msg), nil
+	}
+}
+
+func length(strfn, slicefn, mapfn func(int, int, string) validation.Rule) Factory {
+	return func(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+		if err := nargs(args, 2); err != nil {
+			return nil, err
+		}
+		min, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, err
+		}
+		max, err := strconv.Atoi(args[1])
+		if err != nil {
+			return nil, err
+		}
+		switch t.Kind() {
+		case reflect.String:
+			return strfn(min, max, msg), nil
+		case reflect.Slice:
+			return slicefn(min, max, msg), nil
+		case reflect.Map:
+			return mapfn(min, max, msg), nil
+		default:
+			return nil, unsupported(t)
+		}
+	}
+}
+
+func bound(strfn, slicefn, mapfn func(int, string) validation.Rule) Factory {
+	return func(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+		if err := nargs(args, 1); err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, err
+		}
+		switch t.Kind() {
+		case reflect.String:
+			return strfn(n, msg), nil
+		case reflect.Slice:
+			return slicefn(n, msg), nil
+		case reflect.Map:
+			return mapfn(n, msg), nil
+		default:
+			return nil, unsupported(t)
+		}
+	}
+}
+
+func number(fn func(interface{}, string) validation.Rule) Factory {
+	return func(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+		if err := nargs(args, 1); err != nil {
+			return nil, err
+		}
+		if !orderedTypes[t] {
+			return nil, unsupported(t)
+		}
+		x, err := value(t, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return fn(x, msg), nil
+	}
+}
+
+func between(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+	if err := nargs(args, 2); err != nil {
+		return nil, err
+	}
+	if !orderedTypes[t] {
+		return nil, unsupported(t)
+	}
+	a, err := value(t, args[0])
+	if err != nil {
+		return nil, err
+	}
+	b, err := value(t, args[1])
+	if err != nil {
+		return nil, err
+	}
+	return rule.Between(a, b, msg), nil
+}
+
+func in(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("expected at least 1 argument")
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		x, err := value(t, arg)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = x
+	}
+	return rule.In(values, msg), nil
+}
+
+func match(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+	if err := nargs(args, 1); err != nil {
+		return nil, err
+	}
+	if t.Kind() != reflect.String {
+		return nil, unsupported(t)
+	}
+	pattern, err := regexp.Compile(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return rule.StrMatch(pattern, msg), nil
+}
+
+func unique(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+	if err := nargs(args, 0); err != nil {
+		return nil, err
+	}
+	if t.Kind() != reflect.Slice || !t.Elem().Comparable() {
+		return nil, unsupported(t)
+	}
+	return rule.SliceUnique(index, msg), nil
+}
+
+func sliceEach(rules []validation.Rule) validation.Rule {
+	return rule.SliceEach(index, rules)
+}
+
+func index(v interface{}, i int) interface{} {
+	return reflect.ValueOf(v).Elem().Index(i).Addr().Interface()
+}
+
+// value parses a rule argument as a value of type t.
+func value(t reflect.Type, s string) (interface{}, error) {
+	if t == timeType {
+		return time.Parse(time.RFC3339, s)
+	}
+
+	var x interface{}
+	var err error
+
+	switch t.Kind() {
+	case reflect.String:
+		x = s
+	case reflect.Int:
+		x, err = strconv.Atoi(s)
+	case reflect.Uint:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, 0)
+		x = uint(n)
+	case reflect.Float32:
+		var n float64
+		n, err = strconv.ParseFloat(s, 32)
+		x = float32(n)
+	case reflect.Float64:
+		x, err = strconv.ParseFloat(s, 64)
+	default:
+		return nil, unsupported(t)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reflect.ValueOf(x).Convert(t).Interface(), nil
+}
@@ -0,0 +1,348 @@
+// Package tags builds validation rules from struct tags.
+//
+// Rules are declared in the `validate` struct tag as a comma separated list
+// of names with optional space separated arguments, e.g.
+//
+//	type User struct {
+//		Email string   `json:"email" validate:"required,maxLen=64,email"`
+//		Age   int      `json:"age" validate:"between=18 120"`
+//		Tags  []string `json:"tags" validate:"maxLen=8,unique,dive,minLen=2"`
+//		Zip   string   `json:"zip" validate:"match='^\\d{3,5}$'"`
+//	}
+//
+// Arguments containing commas or spaces are put in single quotes, a single
+// quote within a quoted argument is doubled, backslashes are escaped as in
+// any struct tag value, e.g.
+//
+//	Kind string `validate:"in='it''s' 'a, b'"`
+//
+// The rules following `dive` are applied to each item of a slice, the rules
+// following `optional` are applied to the value of a pointer if it is not
+// nil. Fields of struct types having `validate` tags and slices of such
+// structs are validated recursively.
+package tags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+// Key is the name of the struct tag holding validation rules.
+const Key = "validate"
+
+const (
+	dive     = "dive"
+	optional = "optional"
+)
+
+// Factory creates a rule for a field of type t. The args are taken from the
+// tag and msg is taken from the registry catalog.
+type Factory func(t reflect.Type, args []string, msg string) (validation.Rule, error)
+
+// Catalog maps rule names to messages.
+type Catalog map[string]string
+
+// Registry maps tag rule names to rule factories.
+type Registry struct {
+	factories map[string]Factory
+	catalog   Catalog
+}
+
+// NewRegistry creates a registry with the built-in rules and the message
+// catalog provided.
+func NewRegistry(catalog Catalog) *Registry {
+	r := &Registry{
+		factories: map[string]Factory{},
+		catalog:   catalog,
+	}
+	for name, f := range builtins {
+		r.factories[name] = f
+	}
+	return r
+}
+
+// Default is the registry used by the package level Struct.
+var Default = NewRegistry(DefaultCatalog)
+
+// Register adds a rule factory to the registry, the rule message is looked
+// up in the registry catalog by name.
+func (r *Registry) Register(name string, f Factory) {
+	r.factories[name] = f
+}
+
+// Struct creates a struct validation rule from the `validate` tags of the
+// struct pointed by v. The tag and the options have the same meaning as in
+// validation.Struct, they apply to the nested structs too. Recursive types
+// are supported, e.g. a struct having a slice of itself.
+func (r *Registry) Struct(v interface{}, tag string, opts ...validation.Option) (validation.Rule, error) {
+	tp := reflect.TypeOf(v)
+	if tp == nil || tp.Kind() != reflect.Ptr || tp.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected pointer to struct but got %v", tp)
+	}
+
+	b := &builder{
+		Registry: r,
+		tag:      tag,
+		opts:     opts,
+		structs:  map[reflect.Type]validation.Rule{},
+		tagged:   map[reflect.Type]bool{},
+	}
+	return b.structRule(tp.Elem())
+}
+
+// Struct creates a struct validation rule using the Default registry.
+func Struct(v interface{}, tag string, opts ...validation.Option) (validation.Rule, error) {
+	return Default.Struct(v, tag, opts...)
+}
+
+// builder builds the rules of a struct and its nested structs. The rules of
+// the nested structs are memoized per type.
+type builder struct {
+	*Registry
+	tag     string
+	opts    []validation.Option
+	structs map[reflect.Type]validation.Rule
+	tagged  map[reflect.Type]bool
+}
+
+// structRule creates the rule of the struct type tp. While the rule is being
+// built, the nested fields of the same type get a reference to it, which is
+// bound on the first value validated, so binding recursive rules terminates.
+func (b *builder) structRule(tp reflect.Type) (validation.Rule, error) {
+	if r, ok := b.structs[tp]; ok {
+		return r, nil
+	}
+
+	var res validation.Rule
+	b.structs[tp] = validation.Described(func(ctx interface{}) func(interface{}) error {
+		var once sync.Once
+		var fn func(interface{}) error
+		return func(v interface{}) error {
+			once.Do(func() {
+				fn = res(ctx)
+			})
+			return fn(v)
+		}
+	}, func() validation.Descriptor {
+		return validation.Descriptor{Name: validation.NameStruct, Type: tp}
+	})
+
+	fields, err := b.fields(tp)
+	if err != nil {
+		delete(b.structs, tp)
+		return nil, err
+	}
+
+	res = validation.Struct(reflect.New(tp).Interface(), b.tag, fields, b.opts...)
+	b.structs[tp] = res
+	return res, nil
+}
+
+func (b *builder) fields(tp reflect.Type) ([]validation.Field, error) {
+	fields := []validation.Field{}
+	for i := 0; i < tp.NumField(); i++ {
+		ft := tp.Field(i)
+
+		spec := ft.Tag.Get(Key)
+		if !ft.IsExported() {
+			if spec != "" {
+				return nil, fmt.Errorf("%s.%s: unexported field", tp.Name(), ft.Name)
+			}
+			continue
+		}
+
+		rules, err := b.rules(ft.Type, spec)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", tp.Name(), ft.Name, err)
+		}
+
+		if len(rules) > 0 {
+			fields = append(fields, validation.Field{
+				Attr:  attr(ft.Index),
+				Rules: rules,
+			})
+		}
+	}
+	return fields, nil
+}
+
+// rules creates rules for a value of type t from the spec provided. Values
+// of struct types are validated recursively.
+func (b *builder) rules(t reflect.Type, spec string) ([]validation.Rule, error) {
+	rules := []validation.Rule{}
+
+	items := []string{}
+	if spec != "" {
+		var err error
+		if items, err = split(spec, ','); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, item := range items {
+		name, args, err := parse(item)
+		if err != nil {
+			return nil, err
+		}
+
+		if name == dive {
+			if t.Kind() != reflect.Slice {
+				return nil, fmt.Errorf("%s is not supported for %v", dive, t)
+			}
+			each, err := b.rules(t.Elem(), strings.Join(items[i+1:], ","))
+			if err != nil {
+				return nil, err
+			}
+			return append(rules, sliceEach(each)), nil
+		}
+
+		if name == optional {
+			if t.Kind() != reflect.Ptr {
+				return nil, fmt.Errorf("%s is not supported for %v", optional, t)
+			}
+			rest, err := b.rules(t.Elem(), strings.Join(items[i+1:], ","))
+			if err != nil {
+				return nil, err
+			}
+			return append(rules, rule.Optional(rest...)), nil
+		}
+
+		f, ok := b.factories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+
+		rule, err := f(t, args, b.message(name))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && b.hasRules(t):
+		nested, err := b.structRule(t)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, nested)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Struct && b.hasRules(t.Elem()):
+		nested, err := b.structRule(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, sliceEach([]validation.Rule{nested}))
+	}
+
+	return rules, nil
+}
+
+func (r *Registry) message(name string) string {
+	if msg, ok := r.catalog[name]; ok {
+		return msg
+	}
+	return name
+}
+
+func parse(item string) (string, []string, error) {
+	item = strings.TrimSpace(item)
+	i := strings.Index(item, "=")
+	if i < 0 {
+		return item, nil, nil
+	}
+	name := item[:i]
+	args, err := split(item[i+1:], ' ')
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return name, args, nil
+}
+
+// split splits s at the separator sep found outside of single quotes. The
+// rule specs are split at commas keeping the quotes and empty specs, the
+// arguments are split at white spaces dropping the quotes and the empty
+// arguments unless they are quoted.
+func split(s string, sep rune) ([]string, error) {
+	unquote := sep == ' '
+
+	var parts []string
+	var b strings.Builder
+	token, quoted := false, false
+
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		c := rs[i]
+		switch {
+		case c == '\'' && quoted && i+1 < len(rs) && rs[i+1] == '\'':
+			if !unquote {
+				b.WriteRune(c)
+			}
+			b.WriteRune(c)
+			i++
+		case c == '\'':
+			if !unquote {
+				b.WriteRune(c)
+			}
+			quoted, token = !quoted, true
+		case !quoted && (c == sep || unquote && unicode.IsSpace(c)):
+			if token || !unquote {
+				parts = append(parts, b.String())
+			}
+			b.Reset()
+			token = false
+		default:
+			b.WriteRune(c)
+			token = true
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	if token || !unquote {
+		parts = append(parts, b.String())
+	}
+	return parts, nil
+}
+
+// hasRules reports whether the struct type tp or the structs nested in it,
+// directly or in slices, have `validate` tags. The result is memoized per
+// type, the types already visited are skipped, so recursive types terminate.
+func (b *builder) hasRules(tp reflect.Type) bool {
+	has, ok := b.tagged[tp]
+	if !ok {
+		has = hasRules(tp, map[reflect.Type]bool{})
+		b.tagged[tp] = has
+	}
+	return has
+}
+
+func hasRules(tp reflect.Type, seen map[reflect.Type]bool) bool {
+	seen[tp] = true
+	for i := 0; i < tp.NumField(); i++ {
+		ft := tp.Field(i)
+		if ft.Tag.Get(Key) != "" {
+			return true
+		}
+		t := ft.Type
+		if t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		if ft.IsExported() && t.Kind() == reflect.Struct && !seen[t] && hasRules(t, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func attr(index []int) validation.Attr {
+	return func(v interface{}) interface{} {
+		return reflect.ValueOf(v).Elem().FieldByIndex(index).Addr().Interface()
+	}
+}
@@ -0,0 +1,303 @@
+package tags_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+	"github.com/vbogretsov/go-validation/tags"
+)
+
+type Status string
+
+type Address struct {
+	Country string `json:"country" validate:"required,upper"`
+	ZipCode string `json:"zipCode" validate:"match=^[0-9]+$"`
+}
+
+type User struct {
+	Email     string    `json:"email" validate:"required,email"`
+	Name      string    `json:"name" validate:"len=2 8"`
+	Age       int       `json:"age" validate:"between=18 120"`
+	Status    Status    `json:"status" validate:"in=active blocked"`
+	Tags      []string  `json:"tags" validate:"maxLen=2,unique,dive,minLen=2"`
+	Address   Address   `json:"address"`
+	Addresses []Address `json:"addresses"`
+	Comment   string    `json:"comment"`
+}
+
+var catalog = tags.Catalog{
+	"required": "ErrRequired",
+	"email":    "ErrEmail",
+	"len":      "ErrLen",
+	"between":  "ErrBetween",
+	"in":       "ErrIn",
+	"maxLen":   "ErrMaxLen",
+	"minLen":   "ErrMinLen",
+	"unique":   "ErrUnique",
+	"upper":    "ErrUpper",
+	"match":    "ErrMatch",
+	"even":     "ErrEven",
+}
+
+func field(name string, errs ...error) validation.StructError {
+	return validation.StructError{Field: name, Errors: errs}
+}
+
+func TestStruct(t *testing.T) {
+	reg := tags.NewRegistry(catalog)
+	fun, err := reg.Struct(&User{}, "json")
+	require.NoError(t, err)
+	validate := fun(nil)
+
+	t.Run("ErrorIfInvalid", func(t *testing.T) {
+		v := User{
+			Email:     "user",
+			Name:      "u",
+			Age:       10,
+			Status:    "deleted",
+			Tags:      []string{"a", "bb", "bb"},
+			Address:   Address{Country: "ru", ZipCode: "12"},
+			Addresses: []Address{{Country: "RU", ZipCode: "x"}},
+		}
+		exp := validation.Errors([]error{
+			field("email", validation.Error{Code: rule.NameStrEmail, Message: "ErrEmail"}),
+			field("name", validation.Error{Code: rule.NameStrLen, Message: "ErrLen", Params: validation.Params{
+				rule.ParamStrMinLen: 2,
+				rule.ParamStrMaxLen: 8,
+			}}),
+			field("age", validation.Error{Code: rule.NameNumBetween, Message: "ErrBetween", Params: validation.Params{
+				rule.ParamNumMin: 18,
+				rule.ParamNumMax: 120,
+			}}),
+			field("status", validation.Error{Code: rule.NameIn, Message: "ErrIn", Params: validation.Params{
+				rule.ParamInUnsupported: Status("deleted"),
+				rule.ParamInSupported:   []interface{}{Status("active"), Status("blocked")},
+			}}),
+			field("tags",
+				validation.Error{Code: rule.NameSliceMaxLen, Message: "ErrMaxLen", Params: validation.Params{
+					rule.ParamSliceMaxLen: 2,
+				}},
+				validation.SliceError{
+					Index:  2,
+					Errors: []error{validation.Error{Code: rule.NameSliceUnique, Message: "ErrUnique"}},
+				},
+				validation.SliceError{
+					Index: 0,
+					Errors: []error{validation.Error{Code: rule.NameStrMinLen, Message: "ErrMinLen", Params: validation.Params{
+						rule.ParamStrMinLen: 2,
+					}}},
+				},
+			),
+			field("address",
+				field("country", validation.Error{Code: rule.NameStrUpperCase, Message: "ErrUpper"}),
+			),
+			field("addresses",
+				validation.SliceError{
+					Index: 0,
+					Errors: []error{
+						field("zipCode", validation.Error{Code: rule.NameStrMatch, Message: "ErrMatch"}),
+					},
+				},
+			),
+		})
+		require.Equal(t, exp, validate(&v))
+	})
+	t.Run("OkIfValid", func(t *testing.T) {
+		v := User{
+			Email:     "user@mail.com",
+			Name:      "user",
+			Age:       20,
+			Status:    "active",
+			Tags:      []string{"aa", "bb"},
+			Address:   Address{Country: "RU", ZipCode: "12"},
+			Addresses: []Address{{Country: "RU", ZipCode: "1"}},
+		}
+		require.NoError(t, validate(&v))
+	})
+}
+
+func TestStructErrors(t *testing.T) {
+	t.Run("ErrorIfNotPtrToStruct", func(t *testing.T) {
+		_, err := tags.Struct(User{}, "")
+		require.Error(t, err)
+	})
+	t.Run("ErrorIfUnknownRule", func(t *testing.T) {
+		type T struct {
+			A string `validate:"xxx"`
+		}
+		_, err := tags.Struct(&T{}, "")
+		require.Error(t, err)
+	})
+	t.Run("ErrorIfUnsupportedType", func(t *testing.T) {
+		type T struct {
+			A int `validate:"email"`
+		}
+		_, err := tags.Struct(&T{}, "")
+		require.Error(t, err)
+	})
+	t.Run("ErrorIfInvalidArgs", func(t *testing.T) {
+		type T struct {
+			A int `validate:"min=x"`
+		}
+		_, err := tags.Struct(&T{}, "")
+		require.Error(t, err)
+	})
+	t.Run("ErrorIfNamedNumber", func(t *testing.T) {
+		type Level int
+		type T struct {
+			A Level `validate:"min=1"`
+		}
+		_, err := tags.Struct(&T{}, "")
+		require.Error(t, err)
+	})
+	t.Run("ErrorIfNumberRuleOnString", func(t *testing.T) {
+		type T struct {
+			A string `validate:"min=abc"`
+			B string `validate:"between=a b"`
+		}
+		_, err := tags.Struct(&T{}, "")
+		require.Error(t, err)
+	})
+	t.Run("ErrorIfUnterminatedQuote", func(t *testing.T) {
+		type T struct {
+			A string `validate:"match='[a-z]+,required"`
+		}
+		_, err := tags.Struct(&T{}, "")
+		require.Error(t, err)
+	})
+	t.Run("ErrorIfUnexported", func(t *testing.T) {
+		type T struct {
+			a string `validate:"required"`
+		}
+		_, err := tags.Struct(&T{a: "a"}, "")
+		require.Error(t, err)
+	})
+}
+
+func TestQuotedArgs(t *testing.T) {
+	type T struct {
+		Code string `validate:"match='^\\d{1,3}$',required"`
+		Name string `validate:"match='^[a-z ]+$'"`
+		Kind string `validate:"in='it''s' 'a, b' ''"`
+	}
+	fun, err := tags.Struct(&T{}, "")
+	require.NoError(t, err)
+	validate := fun(nil)
+
+	for _, v := range []T{
+		{Code: "123", Name: "john doe", Kind: "it's"},
+		{Code: "1", Name: "x", Kind: "a, b"},
+		{Code: "12", Name: "x", Kind: ""},
+	} {
+		require.NoError(t, validate(&v), v)
+	}
+
+	err = validate(&T{Code: "1234", Name: "John", Kind: "a"})
+	require.Equal(t, validation.Errors{
+		field("Code", validation.Error{Code: rule.NameStrMatch, Message: "has invalid format"}),
+		field("Name", validation.Error{Code: rule.NameStrMatch, Message: "has invalid format"}),
+		field("Kind", validation.Error{Code: rule.NameIn, Message: "is not supported", Params: validation.Params{
+			rule.ParamInUnsupported: "a",
+			rule.ParamInSupported:   []interface{}{"it's", "a, b", ""},
+		}}),
+	}, err)
+}
+
+type Node struct {
+	Name     string `json:"name" validate:"required"`
+	Children []Node `json:"children"`
+}
+
+func TestRecursive(t *testing.T) {
+	fun, err := tags.NewRegistry(catalog).Struct(&Node{}, "json")
+	require.NoError(t, err)
+
+	v := Node{Name: "root", Children: []Node{{Name: "a"}, {Children: []Node{{}}}}}
+	require.Equal(t, validation.Errors{
+		field("children", validation.SliceError{
+			Index: 1,
+			Errors: validation.Errors{
+				field("name", validation.Error{Code: rule.NameStrRequired, Message: "ErrRequired"}),
+				field("children", validation.SliceError{
+					Index: 0,
+					Errors: validation.Errors{
+						field("name", validation.Error{Code: rule.NameStrRequired, Message: "ErrRequired"}),
+					},
+				}),
+			},
+		}),
+	}, fun(nil)(&v))
+
+	d := validation.Describe(fun)
+	require.Equal(t, validation.NameStruct, d.Fields[1].Rules[0].Items[0].Name)
+}
+
+func TestRegister(t *testing.T) {
+	type T struct {
+		N int `validate:"even"`
+	}
+
+	reg := tags.NewRegistry(catalog)
+	reg.Register("even", func(t reflect.Type, args []string, msg string) (validation.Rule, error) {
+		if t.Kind() != reflect.Int {
+			return nil, errors.New("expected int")
+		}
+		return validation.Func(func(v interface{}) error {
+			if *v.(*int)%2 != 0 {
+				return validation.Error{Message: msg}
+			}
+			return nil
+		}), nil
+	})
+
+	fun, err := reg.Struct(&T{}, "")
+	require.NoError(t, err)
+
+	exp := validation.Errors([]error{
+		field("N", validation.Error{Message: "ErrEven"}),
+	})
+	require.Equal(t, exp, fun(nil)(&T{N: 1}))
+	require.NoError(t, fun(nil)(&T{N: 2}))
+}
+
+func TestOptional(t *testing.T) {
+	type Patch struct {
+		Email *string `json:"email" validate:"optional,email"`
+		Age   *int    `json:"age" validate:"required"`
+	}
+
+	fun, err := tags.NewRegistry(catalog).Struct(&Patch{}, "json")
+	require.NoError(t, err)
+
+	age := 10
+	email := "user"
+
+	exp := validation.Errors([]error{
+		field("email", validation.Error{Code: rule.NameStrEmail, Message: "ErrEmail"}),
+		field("age", validation.Error{Code: rule.NameRequired, Message: "ErrRequired"}),
+	})
+	require.Equal(t, exp, fun(nil)(&Patch{Email: &email}))
+	require.NoError(t, fun(nil)(&Patch{Age: &age}))
+}
+
+func TestNaming(t *testing.T) {
+	fun, err := tags.NewRegistry(catalog).Struct(&User{}, "", validation.Naming(validation.SnakeCase))
+	require.NoError(t, err)
+
+	v := User{
+		Email:   "user@mail.com",
+		Name:    "user",
+		Age:     20,
+		Status:  "active",
+		Address: Address{Country: "NL"},
+	}
+	require.Equal(t, validation.Errors{
+		field("address", field("zip_code",
+			validation.Error{Code: rule.NameStrMatch, Message: "ErrMatch"})),
+	}, fun(nil)(&v))
+}
@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RuleOf represents a type-safe validation function over *T.
+type RuleOf[T any] func(interface{}) func(*T) error
+
+// Typed adapts an untyped Rule to a RuleOf[T].
+func Typed[T any](r Rule) RuleOf[T] {
+	return func(ctx interface{}) func(*T) error {
+		fn := r(ctx)
+		return func(v *T) error {
+			return fn(v)
+		}
+	}
+}
+
+// Untyped adapts a RuleOf[T] to an untyped Rule. The rule returns Panic if
+// it gets a value other than *T.
+func (r RuleOf[T]) Untyped() Rule {
+	return func(ctx interface{}) func(interface{}) error {
+		fn := r(ctx)
+		return func(v interface{}) error {
+			p, ok := v.(*T)
+			if !ok {
+				return Panic{
+					Err: fmt.Errorf("unexpected type: %v", reflect.TypeOf(v)),
+				}
+			}
+			return fn(p)
+		}
+	}
+}
+
+// FuncOf creates a RuleOf[T] from function. The rule is skipped in a dry
+// run.
+func FuncOf[T any](r func(*T) error) RuleOf[T] {
+	return func(ctx interface{}) func(*T) error {
+		if DryRun(ctx) {
+			return func(*T) error { return nil }
+		}
+		return r
+	}
+}
+
+// RulesOf combines several typed rules into single one.
+func RulesOf[T any](rules ...RuleOf[T]) RuleOf[T] {
+	return Typed[T](Rules(untyped(rules)))
+}
+
+// FieldOf represents a type-safe schema field of the struct S.
+type FieldOf[S any] struct {
+	field Field
+}
+
+// Field returns the untyped schema field.
+func (f FieldOf[S]) Field() Field {
+	return f.field
+}
+
+// FieldFor creates a type-safe schema field of the struct S. The compiler
+// checks that all the rules provided accept the field type.
+func FieldFor[S, F any](attr func(*S) *F, rules ...RuleOf[F]) FieldOf[S] {
+	return FieldOf[S]{field: Field{
+		Attr: func(v interface{}) interface{} {
+			return attr(v.(*S))
+		},
+		Rules: untyped(rules),
+	}}
+}
+
+// StructOf creates a type-safe struct validation rule.
+func StructOf[S any](tag string, fields ...FieldOf[S]) RuleOf[S] {
+	fs := make([]Field, len(fields))
+	for i, f := range fields {
+		fs[i] = f.field
+	}
+	return Typed[S](Struct(new(S), tag, fs))
+}
+
+func untyped[T any](rules []RuleOf[T]) []Rule {
+	rs := make([]Rule, len(rules))
+	for i, r := range rules {
+		rs[i] = r.Untyped()
+	}
+	return rs
+}
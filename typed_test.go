@@ -0,0 +1,57 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+func typedRequired(s *string) error {
+	if *s == "" {
+		return errors.New(eRequired)
+	}
+	return nil
+}
+
+var addressRuleOf = validation.StructOf[Address](``,
+	validation.FieldFor(func(a *Address) *string { return &a.Country },
+		validation.FuncOf(typedRequired),
+		validation.Typed[string](validation.Func(startsUpperCase)),
+	),
+	validation.FieldFor(func(a *Address) *string { return &a.ZipCode },
+		validation.RulesOf(
+			validation.FuncOf(typedRequired),
+			validation.Typed[string](validation.Func(zipCode)),
+		),
+	),
+)
+
+func TestTyped(t *testing.T) {
+	t.Run("PanicIfUntypedGetsInvalidType", func(t *testing.T) {
+		fun := validation.FuncOf(typedRequired).Untyped()
+		if err := checkValidatePanics(fun, 10); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("OkIfUntypedGetsValidType", func(t *testing.T) {
+		v := "x"
+		require.NoError(t, validation.FuncOf(typedRequired).Untyped()(nil)(&v))
+	})
+	t.Run("ErrorIfUntypedFails", func(t *testing.T) {
+		v := ""
+		err := validation.FuncOf(typedRequired).Untyped()(nil)(&v)
+		require.Equal(t, errors.New(eRequired), err)
+	})
+}
+
+func TestStructOf(t *testing.T) {
+	for k, v := range addressFixtures {
+		t.Run("ValidateAddress", func(t *testing.T) {
+			err := addressRuleOf(nil)(&k)
+			require.Equal(t, v, err)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package validation
 
 import (
 	"errors"
-	"reflect"
 )
 
 var (
@@ -10,34 +9,70 @@ var (
 	errorAttr = Panic{Err: errors.New("Attr must return a pointer")}
 )
 
-// Rule represents a validation function.
+// Rule represents a validation function. It gets a validation context, which
+// is usually a context.Context, and returns a validator bound to it.
 type Rule func(interface{}) func(interface{}) error
 
 // Attr represents an attribute getter of a struct.
 type Attr func(interface{}) interface{}
 
-// Field represents a schema field.
+// Field represents a schema field. The Mode overrides the validation mode
+// for the field: its rules, the rules nested in them, e.g. the rules of slice
+// items, and whether the struct stops when the field fails. Nested rules get
+// the mode through the context, so they follow it only if the context is nil
+// or a context.Context.
 type Field struct {
 	Attr  Attr
 	Rules []Rule
+	Mode  Mode
 }
 
-// Func creates a Rule from function.
+// Func creates a Rule from function. The rule is skipped in a dry run.
 func Func(r func(interface{}) error) Rule {
-	return func(interface{}) func(interface{}) error { return r }
+	return func(ctx interface{}) func(interface{}) error {
+		if DryRun(ctx) {
+			return skip
+		}
+		return r
+	}
+}
+
+func skip(interface{}) error {
+	return nil
 }
 
 // Rules combines several rules into single one.
 func Rules(rules []Rule) Rule {
-	return func(ctx interface{}) func(v interface{}) error {
-		return func(v interface{}) error {
+	return RulesMode(ModeInherit, rules)
+}
+
+// RulesMode combines several rules into single one validated in the mode
+// provided.
+func RulesMode(mode Mode, rules []Rule) Rule {
+	return Described(func(ctx interface{}) func(v interface{}) error {
+		bail := mode.Or(ModeOf(ctx)).BailRule()
+		fns := bind(rules, ctx)
+		return func(v interface{}) (err error) {
+			i := 0
+			defer func() {
+				if r := recover(); r != nil {
+					err = Locate(Recovered(r), rules[i], v)
+				}
+			}()
+
 			errs := []error{}
-			for _, rule := range rules {
-				if err := rule(ctx)(v); err != nil {
-					if _, ok := err.(Panic); ok {
+			for ; i < len(fns); i++ {
+				if err := Interrupted(ctx); err != nil {
+					return err
+				}
+				if err := fns[i](v); err != nil {
+					if Fatal(err) {
 						return err
 					}
 					errs = append(errs, err)
+					if bail && Failed(err) {
+						break
+					}
 				}
 			}
 			if len(errs) > 0 {
@@ -45,7 +80,17 @@ func Rules(rules []Rule) Rule {
 			}
 			return nil
 		}
+	}, func() Descriptor {
+		return Descriptor{Name: NameRules, Rules: DescribeAll(rules)}
+	})
+}
+
+func bind(rules []Rule, ctx interface{}) []func(interface{}) error {
+	fns := make([]func(interface{}) error, len(rules))
+	for i, rule := range rules {
+		fns[i] = rule(ctx)
 	}
+	return fns
 }
 
 func panicRule(err error) Rule {
@@ -56,98 +101,12 @@ func panicRule(err error) Rule {
 	}
 }
 
-type structRule struct {
-	ftab   map[uintptr]string
-	fields []Field
-}
-
-// Struct struct validation rule.
-func Struct(v interface{}, tag string, fields []Field) Rule {
-	tp := reflect.TypeOf(v)
-	if tp.Kind() != reflect.Ptr {
-		return panicRule(errorArgs)
-	}
-
-	tp = tp.Elem()
-	if tp.Kind() != reflect.Struct {
-		return panicRule(errorArgs)
-	}
-
-	ftab := map[uintptr]string{}
-	for i := 0; i < tp.NumField(); i++ {
-		ft := tp.Field(i)
-		if tag == "" {
-			ftab[ft.Offset] = ft.Name
-		} else {
-			fname := ft.Tag.Get(tag)
-			if fname == "" {
-				fname = ft.Name
-			}
-			ftab[ft.Offset] = fname
-		}
-	}
-
-	s := structRule{
-		ftab:   ftab,
-		fields: fields,
-	}
-
-	return s.validate
-}
-
-func (s structRule) validate(ctx interface{}) func(interface{}) error {
-	return func(v interface{}) error {
-		tp := reflect.TypeOf(v)
-		if tp.Kind() != reflect.Ptr {
-			return errorArgs
-		}
-
-		tp = tp.Elem()
-		if tp.Kind() != reflect.Struct {
-			return errorArgs
-		}
-
-		self := reflect.ValueOf(v).Pointer()
-
-		errs := []error{}
-		for _, f := range s.fields {
-			attr := f.Attr(v)
-
-			fv := reflect.ValueOf(attr)
-			if fv.Kind() != reflect.Ptr {
-				return errorAttr
-			}
-
-			name := ""
-			if attr != v {
-				name = s.ftab[fv.Pointer()-self]
-			}
-
-			fe := []error{}
-			for _, rule := range f.Rules {
-				if err := rule(ctx)(attr); err != nil {
-					if _, ok := err.(Panic); ok {
-						return err
-					}
-					if e, ok := err.(Errors); ok {
-						for _, i := range e {
-							fe = append(fe, i)
-						}
-					} else {
-						fe = append(fe, err)
-					}
-				}
-			}
-
-			if len(fe) > 0 {
-				errs = append(errs, StructError{Field: name, Errors: fe})
-			}
-		}
-
-		if len(errs) > 0 {
-			return Errors(errs)
-		}
-
-		return nil
+// Struct struct validation rule. The options might change the field naming
+// or verify the schema, see Compile.
+func Struct(v interface{}, tag string, fields []Field, opts ...Option) Rule {
+	s, err := Compile(v, tag, fields, opts...)
+	if err != nil {
+		return panicRule(err)
 	}
+	return Described(s.Rule, s.describe)
 }
@@ -131,3 +131,82 @@ func TestCtxValidation(t *testing.T) {
 		require.Equal(t, e, err)
 	}
 }
+
+type Base struct {
+	ID string `json:"id"`
+}
+
+type Profile struct {
+	Base
+	Name    string  `json:"name"`
+	Address Address `json:"address"`
+}
+
+var profileRule = validation.Struct(&Profile{}, "json", []validation.Field{
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Profile).ID
+		},
+		Rules: []validation.Rule{validation.Func(stringRequired)},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Profile).Address.Country
+		},
+		Rules: []validation.Rule{validation.Func(stringRequired)},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Profile).Name
+		},
+		Rules: []validation.Rule{validation.Func(stringRequired)},
+	},
+	{
+		Attr: func(v interface{}) interface{} {
+			return &v.(*Profile).Address.ZipCode
+		},
+		Rules: []validation.Rule{validation.Func(zipCode)},
+	},
+})
+
+func TestStructDeepFieldPath(t *testing.T) {
+	t.Run("NestedPathIfAttrPointsIntoNestedStruct", func(t *testing.T) {
+		exp := validation.Errors([]error{
+			validation.StructError{
+				Field: "Base",
+				Errors: []error{
+					validation.StructError{
+						Field:  "id",
+						Errors: []error{errors.New(eRequired)},
+					},
+				},
+			},
+			validation.StructError{
+				Field: "address",
+				Errors: []error{
+					validation.StructError{
+						Field:  "country",
+						Errors: []error{errors.New(eRequired)},
+					},
+					validation.StructError{
+						Field:  "zipCode",
+						Errors: []error{errors.New(eZipCode)},
+					},
+				},
+			},
+		})
+		act := profileRule(nil)(&Profile{
+			Name:    "user",
+			Address: Address{ZipCode: "abc"},
+		})
+		require.Equal(t, exp, act)
+	})
+	t.Run("OkIfValid", func(t *testing.T) {
+		v := &Profile{
+			Base:    Base{ID: "1"},
+			Name:    "user",
+			Address: Address{Country: "Russia", ZipCode: "123"},
+		}
+		require.NoError(t, profileRule(nil)(v))
+	})
+}
@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	errorOutside    = errors.New("Attr returns a pointer outside the struct")
+	errorUnresolved = errors.New("Attr panics on both zero and sample values")
+)
+
+// sampleDepth limits the nesting of sample values, so recursive types get
+// finite samples.
+const sampleDepth = 4
+
+type dryRunKey struct{}
+
+// DryRun reports whether the validation context belongs to a dry run of
+// Verify. Rules depending on external resources should not use them in a dry
+// run. Rules created by Func and FuncOf are skipped in a dry run.
+func DryRun(ctx interface{}) bool {
+	if c, ok := ctx.(context.Context); ok {
+		d, _ := c.Value(dryRunKey{}).(bool)
+		return d
+	}
+	return false
+}
+
+// Verify makes Compile and Struct check the schema before it is used. Every
+// Attr is called with a zero value and with a sample value having non nil
+// pointers, the pointer returned should address a field of the struct. Then
+// the rules of every field are dry run against the field of the sample.
+// Slices and maps of the sample have a single item, so the item rules run
+// too. Only described rules are dry run, custom rules, e.g. created by Func,
+// and the rules having them as children might expect a context of another
+// type or use external resources, so they are skipped. Every mistake found is
+// reported as a Panic located at the field, all of them are returned
+// together as a Panic wrapping *VerifyError.
+func Verify() Option {
+	return func(s *Schema) {
+		s.verify = true
+	}
+}
+
+// check verifies the fields of the schema.
+func (s *Schema) check(fields []Field) error {
+	zero := reflect.New(s.typ.Elem()).Interface()
+	full := sample(s.typ, 0).Interface()
+	ctx := context.WithValue(context.Background(), dryRunKey{}, true)
+
+	var errs Errors
+	for i, f := range fields {
+		path, attr, err := s.checkAttr(f.Attr, zero, full)
+		if err != nil {
+			errs = append(errs, Locate(fieldError(i, err), nil, attr))
+			continue
+		}
+
+		sv, ok := call(f.Attr, full)
+		if !ok {
+			continue
+		}
+		for _, r := range f.Rules {
+			if !described(r) {
+				continue
+			}
+			if err := dryRun(ctx, r, sv); err != nil {
+				errs = append(errs, Locate(err, nil, nil, path...))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return Panic{Err: &VerifyError{Errors: errs}}
+	}
+	return nil
+}
+
+// checkAttr resolves the path of the field pointed by attr. Attr is called
+// with the zero value first, if it panics, e.g. because the field is behind a
+// nil pointer, it is called with the full sample.
+func (s *Schema) checkAttr(attr Attr, zero, full interface{}) (Path, interface{}, error) {
+	v := zero
+	p, ok := call(attr, zero)
+	if !ok {
+		v = full
+		if p, ok = call(attr, full); !ok {
+			return nil, nil, errorUnresolved
+		}
+	}
+
+	if p == v {
+		return nil, nil, nil
+	}
+	if reflect.ValueOf(p).Kind() != reflect.Ptr {
+		return nil, p, errorAttr.Err
+	}
+
+	names, found := s.ftab.lookup(v, p)
+	if fp, ok := call(attr, full); !found && ok {
+		// Fields of structs embedded by pointer are resolved through the
+		// non nil pointers of the sample.
+		names, found = s.ftab.lookup(full, fp)
+	}
+	if !found {
+		return nil, p, errorOutside
+	}
+
+	return fieldPath(names), nil, nil
+}
+
+func fieldError(i int, err error) Panic {
+	return Panic{Err: fmt.Errorf("field %d: %w", i, err)}
+}
+
+// call calls attr, it returns false if attr panics.
+func call(attr Attr, v interface{}) (p interface{}, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return attr(v), true
+}
+
+// described reports whether the rule and all its children are described.
+func described(r Rule) bool {
+	ok := true
+	Describe(r).Walk(func(_ []string, d Descriptor) bool {
+		ok = ok && d.Name != NameCustom
+		return ok
+	})
+	return ok
+}
+
+// dryRun validates v against the rule, it returns only Panic errors.
+func dryRun(ctx context.Context, r Rule, v interface{}) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = Recovered(rec)
+		}
+		if _, ok := err.(Panic); ok {
+			err = Locate(err, r, v)
+		} else {
+			err = nil
+		}
+	}()
+	return r(ctx)(v)
+}
+
+// sample returns a sample value of the type tp. Pointers of the sample are
+// not nil, slices and maps have a single zero item.
+func sample(tp reflect.Type, depth int) reflect.Value {
+	v := reflect.New(tp).Elem()
+	if depth > sampleDepth {
+		return v
+	}
+
+	switch tp.Kind() {
+	case reflect.Ptr:
+		v.Set(sample(tp.Elem(), depth+1).Addr())
+	case reflect.Slice:
+		v.Set(reflect.Append(v, sample(tp.Elem(), depth+1)))
+	case reflect.Map:
+		v.Set(reflect.MakeMap(tp))
+		v.SetMapIndex(sample(tp.Key(), depth+1), sample(tp.Elem(), depth+1))
+	case reflect.Struct:
+		for i := 0; i < tp.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				f.Set(sample(tp.Field(i).Type, depth+1))
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < tp.Len(); i++ {
+			v.Index(i).Set(sample(tp.Elem(), depth+1))
+		}
+	}
+
+	return v
+}
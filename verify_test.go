@@ -0,0 +1,175 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+	"github.com/vbogretsov/go-validation/rule"
+)
+
+var outside = Address{}
+
+func TestVerify(t *testing.T) {
+	t.Run("PanicIfNotStructPtr", func(t *testing.T) {
+		_, err := validation.Compile(Address{}, "", nil, validation.Verify())
+		require.IsType(t, validation.Panic{}, err)
+	})
+	t.Run("OkIfSchemaValid", func(t *testing.T) {
+		_, err := validation.Compile(&Customer{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Customer).Name
+				},
+				Rules: []validation.Rule{
+					rule.StrRequired("blank"),
+					validation.Func(func(interface{}) error {
+						panic("custom rules are skipped")
+					}),
+				},
+			},
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Customer).Addresses
+				},
+				Rules: []validation.Rule{
+					rule.SliceMinLen(1, "empty"),
+					rule.SliceEach(addressIter, []validation.Rule{addressRule}),
+				},
+			},
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Customer).Manager.Email
+				},
+				Rules: []validation.Rule{rule.StrMinLen(1, "blank")},
+			},
+		}, validation.Verify())
+		require.Nil(t, err)
+	})
+	t.Run("ReportsAllMistakes", func(t *testing.T) {
+		_, err := validation.Compile(&Customer{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return v.(*Customer).Name
+				},
+			},
+			{
+				Attr: func(v interface{}) interface{} {
+					return &outside.Country
+				},
+			},
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Customer).Name
+				},
+				Rules: []validation.Rule{
+					rule.StrRequired("blank"),
+					rule.SliceMinLen(1, "empty"),
+					rule.Min(1, "min"),
+				},
+			},
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Customer).Addresses
+				},
+				Rules: []validation.Rule{
+					rule.SliceEach(addressIter, []validation.Rule{
+						validation.Struct(&Address{}, "json", []validation.Field{
+							{
+								Attr: func(v interface{}) interface{} {
+									return &v.(*Address).ZipCode
+								},
+								Rules: []validation.Rule{rule.MapLen(1, 2, "len")},
+							},
+						}),
+					}),
+				},
+			},
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*Customer).Addresses[1].Country
+				},
+			},
+		}, validation.Verify())
+
+		require.IsType(t, validation.Panic{}, err)
+		require.True(t, errors.Is(err, err))
+		var ve *validation.VerifyError
+		require.True(t, errors.As(err, &ve))
+		errs := ve.Errors
+		require.Len(t, errs, 6)
+
+		paths := make([]string, len(errs))
+		rules := make([]string, len(errs))
+		for i, e := range errs {
+			p := e.(validation.Panic)
+			if p.Loc == nil {
+				continue
+			}
+			paths[i] = p.Loc.Path.String()
+			if p.Loc.Rule != nil {
+				rules[i] = p.Loc.Rule.Name
+			}
+		}
+
+		require.Equal(t, []string{
+			"",
+			"",
+			"name",
+			"name",
+			"addresses[0].zipCode",
+			"",
+		}, paths)
+		require.Equal(t, []string{
+			"",
+			"",
+			rule.NameSliceMinLen,
+			rule.NameNumMin,
+			rule.NameMapLen,
+			"",
+		}, rules)
+		require.Equal(t, reflect.TypeOf(""), errs[0].(validation.Panic).Loc.Type)
+		require.EqualError(t, errs[1],
+			"field 1: Attr returns a pointer outside the struct (type *string)")
+		require.EqualError(t, errs[5],
+			"field 4: Attr panics on both zero and sample values")
+	})
+	t.Run("StructPanics", func(t *testing.T) {
+		r := validation.Struct(&Customer{}, "json", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return v.(*Customer).Name
+				},
+			},
+		}, validation.Verify())
+
+		err := r(nil)(&Customer{})
+		require.IsType(t, validation.Panic{}, err)
+		var ve *validation.VerifyError
+		require.True(t, errors.As(err, &ve))
+		require.Len(t, ve.Errors, 1)
+	})
+	t.Run("CustomRulesSkipped", func(t *testing.T) {
+		_, err := validation.Compile(&User{}, "", []validation.Field{
+			{
+				Attr: func(v interface{}) interface{} {
+					return &v.(*User).Email
+				},
+				Rules: []validation.Rule{
+					emailUniq,
+					validation.Rules([]validation.Rule{emailUniq}),
+				},
+			},
+		}, validation.Verify())
+		require.Nil(t, err)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	require.False(t, validation.DryRun(nil))
+	require.False(t, validation.DryRun(context.Background()))
+}
@@ -0,0 +1,132 @@
+package validation
+
+// SegmentKind represents a kind of a path segment.
+type SegmentKind int
+
+// Kinds of path segments.
+const (
+	FieldSegment SegmentKind = iota
+	IndexSegment
+	KeySegment
+)
+
+// Segment represents an element of a path in the error tree: a struct field,
+// a slice index or a map key.
+type Segment struct {
+	Kind  SegmentKind
+	Field string
+	Index int
+	Key   interface{}
+}
+
+// AtField creates a struct field segment.
+func AtField(name string) Segment {
+	return Segment{Kind: FieldSegment, Field: name}
+}
+
+// AtIndex creates a slice index segment.
+func AtIndex(i int) Segment {
+	return Segment{Kind: IndexSegment, Index: i}
+}
+
+// AtKey creates a map key segment.
+func AtKey(k interface{}) Segment {
+	return Segment{Kind: KeySegment, Key: k}
+}
+
+// Path represents a path to a node of the error tree.
+type Path []Segment
+
+// Append returns a copy of the path with the segment appended.
+func (p Path) Append(s Segment) Path {
+	c := make(Path, len(p), len(p)+1)
+	copy(c, p)
+	return append(c, s)
+}
+
+// segment returns the segment of a tree node, it returns false if err is not
+// a node.
+func segment(err error) (Segment, Errors, bool) {
+	switch e := err.(type) {
+	case StructError:
+		return AtField(e.Field), e.Errors, true
+	case SliceError:
+		return AtIndex(e.Index), e.Errors, true
+	case MapError:
+		return AtKey(e.Key), e.Errors, true
+	default:
+		return Segment{}, nil, false
+	}
+}
+
+// node creates a tree node of the segment.
+func node(s Segment, errs Errors) error {
+	switch s.Kind {
+	case IndexSegment:
+		return SliceError{Index: s.Index, Errors: errs}
+	case KeySegment:
+		return MapError{Key: s.Key, Errors: errs}
+	default:
+		return StructError{Field: s.Field, Errors: errs}
+	}
+}
+
+// Walk calls fn for every leaf of the error tree err with the leaf path in
+// depth-first order. Leaves are the errors other than Errors, StructError,
+// SliceError and MapError. Walk stops and returns the error returned by fn
+// if it is not nil.
+func Walk(err error, fn func(path Path, leaf error) error) error {
+	return walk(nil, err, fn)
+}
+
+func walk(path Path, err error, fn func(Path, error) error) error {
+	if errs, ok := err.(Errors); ok {
+		for _, e := range errs {
+			if err := walk(path, e, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if s, errs, ok := segment(err); ok {
+		return walk(path.Append(s), errs, fn)
+	}
+
+	return fn(path, err)
+}
+
+// Map returns a copy of the error tree err with every leaf replaced by the
+// result of fn. A leaf is dropped if fn returns nil, nodes left empty are
+// dropped as well. Map returns nil if the tree has no leaves left.
+func Map(err error, fn func(path Path, leaf error) error) error {
+	return mapTree(nil, err, fn)
+}
+
+func mapTree(path Path, err error, fn func(Path, error) error) error {
+	if errs, ok := err.(Errors); ok {
+		if res := mapAll(path, errs, fn); res != nil {
+			return res
+		}
+		return nil
+	}
+
+	if s, errs, ok := segment(err); ok {
+		if res := mapAll(path.Append(s), errs, fn); res != nil {
+			return node(s, res)
+		}
+		return nil
+	}
+
+	return fn(path, err)
+}
+
+func mapAll(path Path, errs Errors, fn func(Path, error) error) Errors {
+	var res Errors
+	for _, e := range errs {
+		if m := mapTree(path, e, fn); m != nil {
+			res = append(res, m)
+		}
+	}
+	return res
+}
@@ -0,0 +1,107 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vbogretsov/go-validation"
+)
+
+var errTree = validation.Errors{
+	validation.StructError{
+		Field: "email",
+		Errors: validation.Errors{
+			validation.Error{Code: "str.required", Message: "blank"},
+		},
+	},
+	validation.StructError{
+		Field: "addresses",
+		Errors: validation.Errors{
+			validation.SliceError{
+				Index: 2,
+				Errors: validation.Errors{
+					validation.StructError{
+						Field:  "zipCode",
+						Errors: validation.Errors{errors.New("invalid")},
+					},
+				},
+			},
+		},
+	},
+	validation.StructError{
+		Field: "labels",
+		Errors: validation.Errors{
+			validation.MapError{
+				Key:    "env",
+				Errors: validation.Errors{validation.Error{Code: "str.max_len", Message: "long"}},
+			},
+		},
+	},
+}
+
+func TestWalk(t *testing.T) {
+	var paths []validation.Path
+	var leaves []string
+
+	err := validation.Walk(errTree, func(path validation.Path, leaf error) error {
+		paths = append(paths, path)
+		leaves = append(leaves, leaf.Error())
+		return nil
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"blank", "invalid", "long"}, leaves)
+	require.Equal(t, []validation.Path{
+		{validation.AtField("email")},
+		{validation.AtField("addresses"), validation.AtIndex(2), validation.AtField("zipCode")},
+		{validation.AtField("labels"), validation.AtKey("env")},
+	}, paths)
+}
+
+func TestWalkStops(t *testing.T) {
+	stop := errors.New("stop")
+	n := 0
+	err := validation.Walk(errTree, func(validation.Path, error) error {
+		n++
+		return stop
+	})
+	require.Equal(t, stop, err)
+	require.Equal(t, 1, n)
+}
+
+func TestMap(t *testing.T) {
+	t.Run("DropsEmptyNodes", func(t *testing.T) {
+		res := validation.Map(errTree, func(path validation.Path, leaf error) error {
+			if _, ok := leaf.(validation.Error); ok {
+				return nil
+			}
+			return leaf
+		})
+		require.Equal(t, validation.Errors{errTree[1]}, res)
+	})
+	t.Run("Rewrites", func(t *testing.T) {
+		res := validation.Map(errTree, func(path validation.Path, leaf error) error {
+			if len(path) == 1 {
+				return errors.New("top")
+			}
+			return leaf
+		})
+		require.Equal(t, "email: top, addresses: 2: zipCode: invalid, labels: env: long", res.Error())
+	})
+	t.Run("NilIfAllDropped", func(t *testing.T) {
+		res := validation.Map(errTree, func(validation.Path, error) error {
+			return nil
+		})
+		require.Nil(t, res)
+	})
+	t.Run("Leaf", func(t *testing.T) {
+		leaf := errors.New("leaf")
+		res := validation.Map(leaf, func(path validation.Path, e error) error {
+			require.Empty(t, path)
+			return e
+		})
+		require.Equal(t, leaf, res)
+	})
+}